@@ -0,0 +1,161 @@
+package xlsxfin
+
+import (
+	"math"
+	"sort"
+)
+
+// RateReset changes the rate per period to Rate from Period on.
+type RateReset struct {
+	Period int
+	Rate   float64
+}
+
+// PaymentAdjustment decides when an AdjustableLoan recomputes its payment.
+type PaymentAdjustment int
+
+const (
+	// ResetPayment recomputes the payment whenever the rate changes, as Pmt
+	// of the balance over the periods left.
+	ResetPayment PaymentAdjustment = iota
+	// FiveYearRule keeps the payment for 60 periods whatever the rate, then
+	// recomputes it at most 125% of the previous one (5年ルール, 125%ルール).
+	// Interest the payment does not cover is carried as unpaid interest.
+	FiveYearRule
+)
+
+// AdjustableLoan is a loan whose rate changes at Resets. Base holds the
+// initial Rate, the term, Pv and Fv; only EndOfPeriod is supported, and Pv
+// must be above -Fv, as for Loan.Prepay.
+//
+// PeriodicCap and LifetimeCap limit the rate like the caps of a US
+// adjustable-rate mortgage, in the same per-period units as Rate; zero
+// means no cap. A reset moves the rate at most PeriodicCap up or down from
+// the rate before it, and never more than LifetimeCap above the initial
+// Rate.
+type AdjustableLoan struct {
+	Base        Loan
+	Resets      []RateReset
+	Adjustment  PaymentAdjustment
+	PeriodicCap float64
+	LifetimeCap float64
+}
+
+func (l AdjustableLoan) Validate() error {
+	if err := l.Base.Validate(); err != nil {
+		return err
+	}
+	if err := checkArgs(l.PeriodicCap, l.LifetimeCap); err != nil {
+		return err
+	}
+	if l.Base.Timing != EndOfPeriod || l.Base.Pv <= l.Base.lastBalance() {
+		return ErrNum
+	}
+	if l.PeriodicCap < 0 || l.LifetimeCap < 0 {
+		return ErrNum
+	}
+	if l.Adjustment != ResetPayment && l.Adjustment != FiveYearRule {
+		return ErrNum
+	}
+	for _, reset := range l.Resets {
+		if err := checkArgs(reset.Rate); err != nil {
+			return err
+		}
+		if reset.Period < 1 || reset.Period > l.Base.Nper || reset.Rate <= -1 {
+			return ErrNum
+		}
+	}
+	return nil
+}
+
+// Rates returns the rate of every period after the caps, or nil if the loan
+// fails Validate.
+func (l AdjustableLoan) Rates() []float64 {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	resets := make([]RateReset, len(l.Resets))
+	copy(resets, l.Resets)
+	sort.SliceStable(resets, func(i, j int) bool { return resets[i].Period < resets[j].Period })
+
+	rates := make([]float64, l.Base.Nper)
+	rate := l.Base.Rate
+	next := 0
+	for i := range rates {
+		for ; next < len(resets) && resets[next].Period == i+1; next++ {
+			newRate := resets[next].Rate
+			if l.PeriodicCap > 0 {
+				newRate = math.Max(rate-l.PeriodicCap, math.Min(rate+l.PeriodicCap, newRate))
+			}
+			if l.LifetimeCap > 0 {
+				newRate = math.Min(l.Base.Rate+l.LifetimeCap, newRate)
+			}
+			rate = newRate
+		}
+		rates[i] = rate
+	}
+	return rates
+}
+
+// Schedule returns the loan's schedule under its rates and payment
+// adjustment, or nil if the loan fails Validate.
+//
+// Interest is the rate of the period times the opening balance. A payment
+// pays the unpaid interest first, then the period's interest, then
+// principal; what it cannot cover is added to UnpaidInterest, which earns
+// no interest. Payment is therefore Interest plus Principal plus the change
+// in UnpaidInterest. The last payment repays the balance and any unpaid
+// interest, so the balance closes at -Fv.
+func (l AdjustableLoan) Schedule() Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	base := l.Base
+	rates := l.Rates()
+	target := -base.Fv
+	// A reset at period 1 replaces the initial rate before any payment.
+	payment := newAnnuity(rates[0], float64(base.Nper)).pmt(base.Pv, base.Fv, false)
+	schedule := make(Schedule, base.Nper)
+	opening := base.Pv
+	unpaid := 0.0
+	cumulativeInterest := 0.0
+	for i := range schedule {
+		per := i + 1
+		rate := rates[i]
+		remaining := float64(base.Nper - i)
+		switch {
+		case l.Adjustment == ResetPayment && i > 0 && rate != rates[i-1]:
+			payment = newAnnuity(rate, remaining).pmt(opening, -target, false)
+		case l.Adjustment == FiveYearRule && i > 0 && i%60 == 0:
+			payment = math.Max(newAnnuity(rate, remaining).pmt(opening, -target, false), 1.25*payment)
+		}
+
+		interest := -rate * opening
+		installment := Installment{
+			Period:         per,
+			Payment:        payment,
+			Interest:       interest,
+			OpeningBalance: opening,
+		}
+		if per == base.Nper {
+			installment.Principal = target - opening
+			installment.Payment = interest + installment.Principal - unpaid
+			unpaid = 0
+		} else if due := unpaid - interest; -payment >= due {
+			installment.Principal = payment + due
+			unpaid = 0
+		} else {
+			unpaid = due + payment
+		}
+
+		cumulativeInterest += interest
+		installment.ClosingBalance = opening + installment.Principal
+		installment.CumulativeInterest = cumulativeInterest
+		installment.UnpaidInterest = unpaid
+		schedule[i] = installment
+		opening = installment.ClosingBalance
+	}
+	return schedule
+}
@@ -0,0 +1,206 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAdjustableLoan_Schedule() {
+	loan := AdjustableLoan{
+		Base:       Loan{Rate: 0.01 / 12, Nper: 420, Pv: 30_000_000},
+		Resets:     []RateReset{{Period: 13, Rate: 0.05 / 12}},
+		Adjustment: FiveYearRule,
+	}
+	schedule := loan.Schedule()
+	for _, per := range []int{12, 13, 60, 61} {
+		installment := schedule[per-1]
+		fmt.Printf("%d %.0f %.0f %.0f\n", per, installment.Payment, installment.Interest, installment.UnpaidInterest)
+	}
+	// Output:
+	// 12 -84686 -24451 0
+	// 13 -84686 -122002 37316
+	// 60 -84686 -122002 1791182
+	// 61 -105857 -122002 1807327
+}
+
+func TestAdjustableLoanValidate(t *testing.T) {
+	loan := Loan{Rate: 0.01 / 12, Nper: 420, Pv: 30_000_000}
+	assert.NoError(t, AdjustableLoan{Base: loan}.Validate())
+
+	for _, invalid := range []AdjustableLoan{
+		{Base: Loan{Rate: 0.01, Nper: 0, Pv: 1_000}},
+		{Base: Loan{Rate: 0.01, Nper: 12, Pv: 1_000, Timing: BeginningOfPeriod}},
+		{Base: loan, Resets: []RateReset{{Period: 0, Rate: 0.01}}},
+		{Base: loan, Resets: []RateReset{{Period: 421, Rate: 0.01}}},
+		{Base: loan, Resets: []RateReset{{Period: 12, Rate: -1}}},
+		{Base: loan, PeriodicCap: -0.001},
+		{Base: loan, LifetimeCap: -0.001},
+		{Base: loan, Adjustment: 2},
+		{Base: Loan{Rate: 0.02 / 12, Nper: 120, Pv: -1_000_000}},
+		{Base: Loan{Rate: 0.02 / 12, Nper: 120, Pv: 1_000_000, Fv: -1_000_000}},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), ErrNum, fmt.Sprint(invalid))
+		assert.Nil(t, invalid.Rates())
+		assert.Nil(t, invalid.Schedule())
+	}
+
+	err := AdjustableLoan{Base: loan, Resets: []RateReset{{Period: 12, Rate: math.NaN()}}}.Validate()
+	assert.ErrorIs(t, err, ErrValue)
+
+	// The carryover and the 125% cap assume the borrower pays, so a loan
+	// the other way round is rejected like by Loan.Prepay.
+	deposit := AdjustableLoan{Base: Loan{Rate: 0.02 / 12, Nper: 120, Pv: -1_000_000}, Adjustment: FiveYearRule}
+	assert.ErrorIs(t, deposit.Validate(), ErrNum)
+	assert.Nil(t, deposit.Schedule())
+}
+
+func TestAdjustableLoanRates(t *testing.T) {
+	loan := AdjustableLoan{
+		Base:        Loan{Rate: 0.03, Nper: 10, Pv: 1_000},
+		Resets:      []RateReset{{Period: 7, Rate: 0.1}, {Period: 3, Rate: 0.06}, {Period: 5, Rate: 0.01}, {Period: 6, Rate: 0.08}},
+		PeriodicCap: 0.02,
+		LifetimeCap: 0.045,
+	}
+	expected := []float64{0.03, 0.03, 0.05, 0.05, 0.03, 0.05, 0.07, 0.07, 0.07, 0.07}
+	for i, rate := range loan.Rates() {
+		assert.InDelta(t, expected[i], rate, 1e-15, i+1)
+	}
+
+	loan.PeriodicCap, loan.LifetimeCap = 0, 0
+	assert.Equal(t, []float64{0.03, 0.03, 0.06, 0.06, 0.01, 0.08, 0.1, 0.1, 0.1, 0.1}, loan.Rates())
+}
+
+// checkAdjustableSchedule checks the identities every schedule of an
+// AdjustableLoan keeps.
+func checkAdjustableSchedule(t *testing.T, loan AdjustableLoan, schedule Schedule) {
+	args := fmt.Sprint(loan)
+	assert.Len(t, schedule, loan.Base.Nper, args)
+	rates := loan.Rates()
+	unpaid := 0.0
+	for i, installment := range schedule {
+		assert.Equal(t, i+1, installment.Period, args)
+		assert.InDelta(t, -rates[i]*installment.OpeningBalance, installment.Interest, DELTA, "%v %d", args, i+1)
+		assert.InDelta(t, installment.Interest+installment.Principal+installment.UnpaidInterest-unpaid, installment.Payment, DELTA, "%v %d", args, i+1)
+		assert.InDelta(t, installment.OpeningBalance+installment.Principal, installment.ClosingBalance, DELTA, "%v %d", args, i+1)
+		assert.GreaterOrEqual(t, installment.UnpaidInterest, 0.0, args)
+		if i > 0 {
+			assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, args)
+		}
+		unpaid = installment.UnpaidInterest
+	}
+	assert.Equal(t, loan.Base.Pv, schedule[0].OpeningBalance, args)
+	assert.InDelta(t, -loan.Base.Fv, schedule[loan.Base.Nper-1].ClosingBalance, DELTA, args)
+	assert.Equal(t, 0.0, schedule[loan.Base.Nper-1].UnpaidInterest, args)
+
+	totals := schedule.Totals()
+	assert.InDelta(t, totals.Interest+totals.Principal, totals.Payment, DELTA, args)
+	assert.InDelta(t, totals.Interest, schedule.TotalInterest(), DELTA, args)
+}
+
+func TestAdjustableLoanSchedule(t *testing.T) {
+	t.Run("without resets", func(t *testing.T) {
+		for _, adjustment := range []PaymentAdjustment{ResetPayment, FiveYearRule} {
+			for _, loan := range []Loan{
+				{Rate: 0.035 / 12, Nper: 420, Pv: 30_000_000},
+				{Rate: 0, Nper: 36, Pv: 800_000, Fv: 100_000},
+				{Rate: -0.001, Nper: 24, Pv: 1_000_000},
+			} {
+				adjustable := AdjustableLoan{Base: loan, Adjustment: adjustment}
+				schedule := adjustable.Schedule()
+				checkAdjustableSchedule(t, adjustable, schedule)
+				for i, installment := range loan.Schedule() {
+					assert.InDelta(t, installment.Payment, schedule[i].Payment, DELTA, "%v %d", loan, i+1)
+					assert.InDelta(t, installment.Interest, schedule[i].Interest, DELTA, "%v %d", loan, i+1)
+					assert.InDelta(t, installment.ClosingBalance, schedule[i].ClosingBalance, DELTA, "%v %d", loan, i+1)
+				}
+			}
+		}
+	})
+
+	t.Run("reset payment", func(t *testing.T) {
+		loan := AdjustableLoan{
+			Base:   Loan{Rate: 0.03 / 12, Nper: 360, Pv: 400_000, Fv: 50_000},
+			Resets: []RateReset{{Period: 61, Rate: 0.06 / 12}, {Period: 73, Rate: 0.05 / 12}, {Period: 85, Rate: 0.05 / 12}},
+		}
+		schedule := loan.Schedule()
+		checkAdjustableSchedule(t, loan, schedule)
+
+		assert.Equal(t, loan.Base.Pmt(), schedule[59].Payment)
+		for _, per := range []int{61, 73} {
+			installment := schedule[per-1]
+			rate := loan.Rates()[per-1]
+			assert.Equal(t, PmtF64(rate, loan.Base.Nper-per+1, installment.OpeningBalance, loan.Base.Fv, false), installment.Payment, per)
+			assert.Equal(t, installment.Payment, schedule[per].Payment, per)
+		}
+		// The reset at 85 keeps the rate, so the payment stays.
+		assert.InDelta(t, schedule[83].Payment, schedule[84].Payment, DELTA)
+		for _, installment := range schedule {
+			assert.Equal(t, 0.0, installment.UnpaidInterest)
+		}
+	})
+
+	t.Run("reset at the first period", func(t *testing.T) {
+		for _, adjustment := range []PaymentAdjustment{ResetPayment, FiveYearRule} {
+			loan := AdjustableLoan{
+				Base:       Loan{Rate: 0.01 / 12, Nper: 120, Pv: 10_000_000},
+				Resets:     []RateReset{{Period: 1, Rate: 0.05 / 12}},
+				Adjustment: adjustment,
+			}
+			schedule := loan.Schedule()
+			checkAdjustableSchedule(t, loan, schedule)
+
+			expected := PmtF64(0.05/12, 120, 10_000_000, 0, false)
+			assert.InDelta(t, -106_065.52, expected, 0.01)
+			for i, installment := range schedule {
+				assert.InDelta(t, expected, installment.Payment, DELTA, "%v %d", adjustment, i+1)
+				assert.Equal(t, 0.0, installment.UnpaidInterest, adjustment, i+1)
+			}
+		}
+	})
+
+	t.Run("five-year rule", func(t *testing.T) {
+		loan := AdjustableLoan{
+			Base:       Loan{Rate: 0.01 / 12, Nper: 420, Pv: 30_000_000},
+			Resets:     []RateReset{{Period: 13, Rate: 0.05 / 12}, {Period: 181, Rate: 0.005 / 12}},
+			Adjustment: FiveYearRule,
+		}
+		schedule := loan.Schedule()
+		checkAdjustableSchedule(t, loan, schedule)
+
+		for per := 1; per <= 60; per++ {
+			assert.Equal(t, loan.Base.Pmt(), schedule[per-1].Payment, per)
+		}
+		// The interest outgrows the payment, which rises 25% at a time.
+		assert.Greater(t, schedule[59].UnpaidInterest, 0.0)
+		assert.Equal(t, 0.0, schedule[59].Principal)
+		assert.Equal(t, 1.25*schedule[59].Payment, schedule[60].Payment)
+		assert.Equal(t, 1.25*schedule[60].Payment, schedule[120].Payment)
+
+		// After the rate falls the payment is recomputed in full and pays
+		// off unpaid interest before any principal.
+		installment := schedule[180]
+		assert.Equal(t, PmtF64(0.005/12, 240, installment.OpeningBalance, 0, false), installment.Payment)
+		assert.Less(t, installment.UnpaidInterest, schedule[179].UnpaidInterest)
+		assert.Equal(t, 0.0, installment.Principal)
+	})
+
+	t.Run("unpaid interest at maturity", func(t *testing.T) {
+		loan := AdjustableLoan{
+			Base:       Loan{Rate: 0.01, Nper: 24, Pv: 100_000},
+			Resets:     []RateReset{{Period: 2, Rate: 0.1}},
+			Adjustment: FiveYearRule,
+		}
+		schedule := loan.Schedule()
+		checkAdjustableSchedule(t, loan, schedule)
+
+		// Nothing is repaid after the first period, so the last payment
+		// repays the balance and all the unpaid interest.
+		last := schedule[23]
+		assert.Equal(t, schedule[0].ClosingBalance, last.OpeningBalance)
+		assert.Greater(t, schedule[22].UnpaidInterest, 0.0)
+		assert.InDelta(t, -last.OpeningBalance+last.Interest-schedule[22].UnpaidInterest, last.Payment, DELTA)
+	})
+}
@@ -0,0 +1,65 @@
+package xlsxfin
+
+// The Batch functions evaluate a function over a whole portfolio at once:
+// element i of out receives the result for element i of every argument
+// slice, with one timing for all of them. They do not allocate, and they
+// panic unless every slice has the same length.
+//
+// The annuity factors, which cost the exponentials, are computed once for
+// each run of consecutive loans with the same rate and nper, so sorting a
+// portfolio by product first makes a batch faster. Results are identical to
+// calling the F64 function on each element.
+
+func checkBatch(name string, n int, lengths ...int) {
+	for _, length := range lengths {
+		if length != n {
+			panic("xlsxfin: " + name + ": slices have different lengths")
+		}
+	}
+}
+
+// batchAnnuity returns the annuity factors for rate and nper, reusing a when
+// they are the same as the previous loan's.
+func batchAnnuity(a annuity, i int, rate float64, nper int) annuity {
+	if i > 0 && a.rate == rate && a.nper == float64(nper) {
+		return a
+	}
+	return newAnnuity(rate, float64(nper))
+}
+
+func PmtBatch(rates []float64, npers []int, pvs []float64, fvs []float64, timing PaymentTiming, out []float64) {
+	checkBatch("PmtBatch", len(out), len(rates), len(npers), len(pvs), len(fvs))
+
+	due := timing == BeginningOfPeriod
+	var a annuity
+	for i := range out {
+		a = batchAnnuity(a, i, rates[i], npers[i])
+		out[i] = a.pmt(pvs[i], fvs[i], due)
+	}
+}
+
+func IpmtBatch(rates []float64, pers []int, npers []int, pvs []float64, fvs []float64, timing PaymentTiming, out []float64) {
+	checkBatch("IpmtBatch", len(out), len(rates), len(pers), len(npers), len(pvs), len(fvs))
+
+	due := timing == BeginningOfPeriod
+	var a annuity
+	for i := range out {
+		a = batchAnnuity(a, i, rates[i], npers[i])
+		out[i] = a.ipmt(float64(pers[i]), pvs[i], fvs[i], due)
+	}
+}
+
+func PpmtBatch(rates []float64, pers []int, npers []int, pvs []float64, fvs []float64, timing PaymentTiming, out []float64) {
+	checkBatch("PpmtBatch", len(out), len(rates), len(pers), len(npers), len(pvs), len(fvs))
+
+	due := timing == BeginningOfPeriod
+	var a annuity
+	for i := range out {
+		a = batchAnnuity(a, i, rates[i], npers[i])
+		if pers[i] < 1 || pers[i] >= npers[i]+1 {
+			out[i] = 0
+			continue
+		}
+		out[i] = a.pmt(pvs[i], fvs[i], due) - a.ipmt(float64(pers[i]), pvs[i], fvs[i], due)
+	}
+}
@@ -0,0 +1,145 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPortfolio struct {
+	rates []float64
+	pers  []int
+	npers []int
+	pvs   []float64
+	fvs   []float64
+}
+
+// newTestPortfolio returns n loans of four products, sorted by product as
+// the Batch functions prefer.
+func newTestPortfolio(n int) testPortfolio {
+	products := []struct {
+		rate float64
+		nper int
+	}{{0.0, 24}, {0.01 / 12, 120}, {0.035 / 12, 420}, {-0.001, 36}}
+
+	p := testPortfolio{
+		rates: make([]float64, n),
+		pers:  make([]int, n),
+		npers: make([]int, n),
+		pvs:   make([]float64, n),
+		fvs:   make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		product := products[i*len(products)/n]
+		p.rates[i] = product.rate
+		p.npers[i] = product.nper
+		p.pers[i] = i%(product.nper+2) - 1
+		p.pvs[i] = float64(1_000_000 + 1_000*i)
+		p.fvs[i] = float64(i % 3 * 10_000)
+	}
+	return p
+}
+
+func ExamplePmtBatch() {
+	out := make([]float64, 2)
+	PmtBatch([]float64{0.08 / 12, 0.08 / 12}, []int{10, 10}, []float64{1_000_000, 2_000_000}, []float64{0, 0}, EndOfPeriod, out)
+	fmt.Printf("%.2f %.2f\n", out[0], out[1])
+	// Output: -103703.21 -207406.42
+}
+
+func TestPmtBatch(t *testing.T) {
+	p := newTestPortfolio(1_000)
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		out := make([]float64, 1_000)
+		PmtBatch(p.rates, p.npers, p.pvs, p.fvs, timing, out)
+		for i, actual := range out {
+			assert.Equal(t, Loan{p.rates[i], p.npers[i], p.pvs[i], p.fvs[i], timing}.Pmt(), actual, i)
+		}
+	}
+
+	assert.Panics(t, func() {
+		PmtBatch(p.rates, p.npers, p.pvs, p.fvs[1:], EndOfPeriod, make([]float64, 1_000))
+	})
+}
+
+func TestIpmtBatch(t *testing.T) {
+	p := newTestPortfolio(1_000)
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		out := make([]float64, 1_000)
+		IpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, timing, out)
+		for i, actual := range out {
+			assert.Equal(t, Loan{p.rates[i], p.npers[i], p.pvs[i], p.fvs[i], timing}.Ipmt(p.pers[i]), actual, i)
+		}
+	}
+
+	assert.Panics(t, func() {
+		IpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, EndOfPeriod, make([]float64, 999))
+	})
+}
+
+func TestPpmtBatch(t *testing.T) {
+	p := newTestPortfolio(1_000)
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		out := make([]float64, 1_000)
+		PpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, timing, out)
+		for i, actual := range out {
+			assert.Equal(t, Loan{p.rates[i], p.npers[i], p.pvs[i], p.fvs[i], timing}.Ppmt(p.pers[i]), actual, i)
+		}
+	}
+
+	assert.Panics(t, func() {
+		PpmtBatch(p.rates[1:], p.pers, p.npers, p.pvs, p.fvs, EndOfPeriod, make([]float64, 1_000))
+	})
+}
+
+func TestBatchAllocs(t *testing.T) {
+	p := newTestPortfolio(100)
+	out := make([]float64, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		PmtBatch(p.rates, p.npers, p.pvs, p.fvs, EndOfPeriod, out)
+		IpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, EndOfPeriod, out)
+		PpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, EndOfPeriod, out)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+const benchmarkLoans = 10_000
+
+func BenchmarkPmtBatch(b *testing.B) {
+	p := newTestPortfolio(benchmarkLoans)
+	out := make([]float64, benchmarkLoans)
+
+	b.Run("batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			PmtBatch(p.rates, p.npers, p.pvs, p.fvs, EndOfPeriod, out)
+		}
+	})
+
+	b.Run("loop", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range out {
+				out[i] = PmtF64(p.rates[i], p.npers[i], p.pvs[i], p.fvs[i], false)
+			}
+		}
+	})
+}
+
+func BenchmarkIpmtBatch(b *testing.B) {
+	p := newTestPortfolio(benchmarkLoans)
+	out := make([]float64, benchmarkLoans)
+
+	b.Run("batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			IpmtBatch(p.rates, p.pers, p.npers, p.pvs, p.fvs, EndOfPeriod, out)
+		}
+	})
+
+	b.Run("loop", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range out {
+				out[i] = IpmtF64(p.rates[i], p.pers[i], p.npers[i], p.pvs[i], p.fvs[i], false)
+			}
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package xlsxfin
+
+// BonusLoan is a mortgage repaid partly by monthly payments and partly by
+// payments in two bonus months a year (ボーナス併用払い), each portion
+// amortized with level payments.
+//
+// BonusRatio of Pv is repaid in the bonus months at AnnualRate/2, one
+// payment every six months starting in month FirstBonus (1 to 6, or 6 when
+// zero), and the rest monthly at AnnualRate/12 over Nper months. Payments
+// are due at the end of each period. The first bonus payment is charged
+// interest for the FirstBonus months since the loan started,
+// AnnualRate*FirstBonus/12.
+type BonusLoan struct {
+	AnnualRate float64
+	Nper       int
+	Pv         float64
+	BonusRatio float64
+	FirstBonus int
+}
+
+func (b BonusLoan) firstBonus() int {
+	if b.FirstBonus == 0 {
+		return 6
+	}
+	return b.FirstBonus
+}
+
+// bonusPayments returns how many bonus months fall within the term.
+func (b BonusLoan) bonusPayments() int {
+	if b.Nper < b.firstBonus() {
+		return 0
+	}
+	return (b.Nper-b.firstBonus())/6 + 1
+}
+
+// Monthly returns the portion repaid every month.
+func (b BonusLoan) Monthly() Loan {
+	return Loan{Rate: b.AnnualRate / 12, Nper: b.Nper, Pv: b.Pv * (1 - b.BonusRatio)}
+}
+
+// bonusPv returns the principal repaid in the bonus months.
+func (b BonusLoan) bonusPv() float64 {
+	return b.Pv * b.BonusRatio
+}
+
+// Bonus returns the portion repaid in the bonus months as a loan with one
+// period per half year. When FirstBonus is less than 6 its first period is
+// shorter, so Pv is the bonus portion grown by the interest of FirstBonus
+// months and discounted by a half year: the loan then has the portion's
+// payments and, from the first bonus month on, its balances.
+func (b BonusLoan) Bonus() Loan {
+	pv := b.bonusPv()
+	if first := b.firstBonus(); first < 6 {
+		pv *= (1 + b.AnnualRate*float64(first)/12) / (1 + b.AnnualRate/2)
+	}
+	return Loan{Rate: b.AnnualRate / 2, Nper: b.bonusPayments(), Pv: pv}
+}
+
+func (b BonusLoan) Validate() error {
+	if err := checkArgs(b.AnnualRate, b.Pv, b.BonusRatio); err != nil {
+		return err
+	}
+	if b.BonusRatio < 0 || b.BonusRatio > 1 || b.FirstBonus < 0 || b.FirstBonus > 6 {
+		return ErrNum
+	}
+	if err := b.Monthly().Validate(); err != nil {
+		return err
+	}
+	if b.BonusRatio == 0 {
+		return nil
+	}
+	return b.Bonus().Validate()
+}
+
+// MonthlyPmt returns the payment due every month.
+func (b BonusLoan) MonthlyPmt() float64 {
+	return b.Monthly().Pmt()
+}
+
+// BonusPmt returns the payment due in each bonus month on top of
+// MonthlyPmt.
+func (b BonusLoan) BonusPmt() float64 {
+	if b.bonusPayments() == 0 {
+		return 0
+	}
+	return b.Bonus().Pmt()
+}
+
+// IsBonusMonth reports whether a bonus payment is due in period per.
+func (b BonusLoan) IsBonusMonth(per int) bool {
+	return per >= b.firstBonus() && per <= b.Nper && (per-b.firstBonus())%6 == 0
+}
+
+// Schedule returns the monthly schedule of the whole loan, or nil if it
+// fails Validate. Each row adds the bonus portion's installment to the
+// monthly one in bonus months, and its balances are those of both portions
+// together.
+func (b BonusLoan) Schedule() Schedule {
+	if b.Validate() != nil {
+		return nil
+	}
+	identity := func(f float64) float64 { return f }
+	return b.merge(b.Monthly().Schedule(), b.prorate(b.Bonus().Schedule(), identity))
+}
+
+// RoundedSchedule is Schedule with each portion rounded as by
+// Loan.RoundedSchedule, so both the monthly and the bonus payment are whole
+// units and the balance closes exactly at 0. When FirstBonus is less than
+// 6, the first bonus payment also settles the rounding of its shorter
+// period's interest. It returns nil if b fails Validate or if either
+// portion's RoundedSchedule is nil.
+func (b BonusLoan) RoundedSchedule(currency Currency, mode RoundingMode, trueUp TrueUp) Schedule {
+	if b.Validate() != nil {
+		return nil
+	}
+	monthly := b.Monthly().RoundedSchedule(currency, mode, trueUp)
+	if monthly == nil {
+		return nil
+	}
+	portion := b.Bonus()
+	bonus := portion.RoundedSchedule(currency, mode, trueUp)
+	if bonus == nil && portion.Validate() == nil {
+		return nil
+	}
+	round := func(f float64) float64 { return currency.Round(f, mode) }
+	return b.merge(monthly, b.prorate(bonus, round))
+}
+
+// prorate replaces the first installment of the bonus portion's schedule,
+// which Bonus starts a half year before the first bonus month, with the
+// bonus portion's own opening balance and the interest of FirstBonus
+// months. The closing balance stays, and the principal and payment follow,
+// rounded with round.
+func (b BonusLoan) prorate(bonus Schedule, round func(float64) float64) Schedule {
+	first := b.firstBonus()
+	if len(bonus) == 0 || first == 6 {
+		return bonus
+	}
+	installment := &bonus[0]
+	installment.OpeningBalance = round(b.bonusPv())
+	installment.Interest = round(-b.AnnualRate * float64(first) / 12 * b.bonusPv())
+	installment.Principal = round(installment.ClosingBalance - installment.OpeningBalance)
+	installment.Payment = round(installment.Interest + installment.Principal)
+	return bonus
+}
+
+func (b BonusLoan) merge(monthly Schedule, bonus Schedule) Schedule {
+	schedule := make(Schedule, len(monthly))
+	bonusBalance := b.bonusPv()
+	if len(bonus) > 0 {
+		bonusBalance = bonus[0].OpeningBalance
+	}
+	next := 0
+	cumulativeInterest := 0.0
+	for i, installment := range monthly {
+		installment.OpeningBalance += bonusBalance
+		if b.IsBonusMonth(installment.Period) {
+			row := bonus[next]
+			installment.Payment += row.Payment
+			installment.Interest += row.Interest
+			installment.Principal += row.Principal
+			bonusBalance = row.ClosingBalance
+			next++
+		}
+		installment.ClosingBalance += bonusBalance
+		cumulativeInterest += installment.Interest
+		installment.CumulativeInterest = cumulativeInterest
+		schedule[i] = installment
+	}
+	return schedule
+}
@@ -0,0 +1,130 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBonusLoan() {
+	loan := BonusLoan{AnnualRate: 0.015, Nper: 35 * 12, Pv: 30_000_000, BonusRatio: 0.4}
+	fmt.Printf("%.0f %.0f\n", loan.MonthlyPmt(), loan.BonusPmt())
+	// Output: -55113 -220976
+}
+
+func TestBonusLoanValidate(t *testing.T) {
+	assert.NoError(t, BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 0.4}.Validate())
+	assert.NoError(t, BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000}.Validate())
+	assert.ErrorIs(t, BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 1.5}.Validate(), ErrNum)
+	assert.ErrorIs(t, BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, FirstBonus: 7}.Validate(), ErrNum)
+	assert.ErrorIs(t, BonusLoan{AnnualRate: 0.015, Nper: 5, Pv: 30_000_000, BonusRatio: 0.4}.Validate(), ErrNum)
+	assert.NoError(t, BonusLoan{AnnualRate: 0.015, Nper: 5, Pv: 30_000_000}.Validate())
+	assert.ErrorIs(t, BonusLoan{AnnualRate: -12, Nper: 420, Pv: 30_000_000}.Validate(), ErrNum)
+}
+
+func TestBonusLoan(t *testing.T) {
+	loan := BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 0.4, FirstBonus: 3}
+	assert.Equal(t, Loan{Rate: 0.015 / 12, Nper: 420, Pv: 18_000_000}, loan.Monthly())
+	// Three months of interest to the first bonus month instead of six.
+	bonusPv := 12_000_000 * (1 + 0.015*3/12) / 1.0075
+	bonus := loan.Bonus()
+	assert.Equal(t, Loan{Rate: 0.0075, Nper: 70, Pv: bonus.Pv}, bonus)
+	assert.InDelta(t, bonusPv, bonus.Pv, DELTA)
+	assert.InDelta(t, PmtF64(0.0075, 70, bonusPv, 0, false), loan.BonusPmt(), DELTA)
+
+	assert.False(t, loan.IsBonusMonth(1))
+	assert.True(t, loan.IsBonusMonth(3))
+	assert.False(t, loan.IsBonusMonth(6))
+	assert.True(t, loan.IsBonusMonth(9))
+	assert.True(t, loan.IsBonusMonth(417))
+	assert.False(t, loan.IsBonusMonth(423))
+	assert.Equal(t, 70, loan.bonusPayments())
+
+	schedule := loan.Schedule()
+	assert.Len(t, schedule, 420)
+	assert.Equal(t, 30_000_000.0, schedule[0].OpeningBalance)
+	assert.InDelta(t, 0, schedule[419].ClosingBalance, DELTA)
+
+	bonusMonths := 0
+	for i, installment := range schedule {
+		if loan.IsBonusMonth(installment.Period) {
+			bonusMonths++
+			assert.InDelta(t, loan.MonthlyPmt()+loan.BonusPmt(), installment.Payment, DELTA, i)
+		} else {
+			assert.InDelta(t, loan.MonthlyPmt(), installment.Payment, DELTA, i)
+		}
+		assert.InDelta(t, installment.Interest+installment.Principal, installment.Payment, DELTA, i)
+		assert.InDelta(t, installment.OpeningBalance+installment.Principal, installment.ClosingBalance, DELTA, i)
+		if i > 0 {
+			assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, i)
+		}
+	}
+	assert.Equal(t, 70, bonusMonths)
+
+	totals := schedule.Totals()
+	assert.InDelta(t, -30_000_000, totals.Principal, DELTA)
+	assert.InDelta(t, loan.Monthly().Cumipmt(1, 420)+70*loan.BonusPmt()+12_000_000, totals.Interest, DELTA)
+	assert.InDelta(t, totals.Interest, schedule.TotalInterest(), DELTA)
+	assert.InDelta(t, loan.Monthly().Ipmt(3)-12_000_000*0.015*3/12, schedule[2].Interest, DELTA)
+	assert.InDelta(t, loan.Monthly().Ipmt(9)+loan.Bonus().Ipmt(2), schedule[8].Interest, DELTA)
+
+	t.Run("first bonus in the first month", func(t *testing.T) {
+		loan := BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 0.4, FirstBonus: 1}
+		schedule := loan.Schedule()
+		assert.InDelta(t, loan.Monthly().Ipmt(1)-15_000, schedule[0].Interest, DELTA)
+		assert.InDelta(t, loan.MonthlyPmt()+loan.BonusPmt(), schedule[0].Payment, DELTA)
+		assert.InDelta(t, 0, schedule[419].ClosingBalance, DELTA)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Nil(t, BonusLoan{AnnualRate: 0.015, Nper: 0, Pv: 1_000}.Schedule())
+	})
+
+	t.Run("without bonus", func(t *testing.T) {
+		for _, nper := range []int{36, 5} {
+			loan := BonusLoan{AnnualRate: 0.015, Nper: nper, Pv: 1_000_000}
+			assert.Equal(t, 0.0, loan.BonusPmt())
+			expected := loan.Monthly().Schedule()
+			actual := loan.Schedule()
+			assert.Len(t, actual, nper)
+			for i := range expected {
+				assert.InDelta(t, expected[i].Payment, actual[i].Payment, DELTA, i)
+				assert.InDelta(t, expected[i].ClosingBalance, actual[i].ClosingBalance, DELTA, i)
+			}
+		}
+	})
+}
+
+func TestBonusLoanRoundedSchedule(t *testing.T) {
+	jpy, _ := LookupCurrency("JPY")
+	loan := BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 0.4}
+	schedule := loan.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp)
+	assert.Len(t, schedule, 420)
+	assert.Equal(t, -55_113.0, schedule[0].Payment)
+	assert.Equal(t, -55_113.0-220_976, schedule[5].Payment)
+	assert.Equal(t, 0.0, schedule[419].ClosingBalance)
+	for i, installment := range schedule {
+		assert.Equal(t, jpy.Money(installment.Payment, RoundHalfAwayFromZero), jpy.Money(installment.Interest, RoundHalfAwayFromZero)+jpy.Money(installment.Principal, RoundHalfAwayFromZero), i)
+		assert.Equal(t, jpy.Money(installment.ClosingBalance, RoundHalfAwayFromZero), jpy.Money(installment.OpeningBalance, RoundHalfAwayFromZero)+jpy.Money(installment.Principal, RoundHalfAwayFromZero), i)
+	}
+	assert.Equal(t, -30_000_000.0, schedule.Totals().Principal)
+
+	t.Run("first bonus before the sixth month", func(t *testing.T) {
+		loan := BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: 0.4, FirstBonus: 3}
+		schedule := loan.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp)
+		monthly := loan.Monthly().RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp)
+		assert.Equal(t, monthly[2].Interest-45_000, schedule[2].Interest)
+		assert.Equal(t, 0.0, schedule[419].ClosingBalance)
+		for i, installment := range schedule {
+			assert.Equal(t, jpy.Money(installment.Payment, RoundHalfAwayFromZero), jpy.Money(installment.Interest, RoundHalfAwayFromZero)+jpy.Money(installment.Principal, RoundHalfAwayFromZero), i)
+			assert.Equal(t, jpy.Money(installment.ClosingBalance, RoundHalfAwayFromZero), jpy.Money(installment.OpeningBalance, RoundHalfAwayFromZero)+jpy.Money(installment.Principal, RoundHalfAwayFromZero), i)
+			if i > 0 {
+				assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, i)
+			}
+		}
+		assert.Equal(t, -30_000_000.0, schedule.Totals().Principal)
+	})
+
+	assert.Nil(t, BonusLoan{AnnualRate: 0.015, Nper: 420, Pv: 30_000_000, BonusRatio: -1}.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp))
+}
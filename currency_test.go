@@ -0,0 +1,112 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePmtCurrency() {
+	usd, _ := LookupCurrency("USD")
+	v := PmtCurrency(usd, RoundHalfAwayFromZero, 0.01, 12, 1_234.56, 0, false)
+	fmt.Println(v)
+	// Output: -109.69
+}
+
+func TestLookupCurrency(t *testing.T) {
+	type testData struct {
+		code     string
+		expected int
+	}
+
+	t.Run("known", func(t *testing.T) {
+		testCases := []testData{
+			{"JPY", 0},
+			{"KRW", 0},
+			{"USD", 2},
+			{"EUR", 2},
+			{"BHD", 3},
+			{"KWD", 3},
+			{"CLF", 4},
+		}
+		for _, testCase := range testCases {
+			currency, ok := LookupCurrency(testCase.code)
+			assert.True(t, ok, testCase.code)
+			assert.Equal(t, testCase.code, currency.Code)
+			assert.Equal(t, testCase.expected, currency.MinorUnits, testCase.code)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		for _, code := range []string{"", "usd", "XXX", "HRK"} {
+			_, ok := LookupCurrency(code)
+			assert.False(t, ok, code)
+		}
+	})
+}
+
+func TestCurrencyMoney(t *testing.T) {
+	type testData struct {
+		currency Currency
+		amount   float64
+		expected Money
+	}
+
+	testCases := []testData{
+		{Currency{"JPY", 0}, -103_703.49, -103_703},
+		{Currency{"JPY", 0}, -103_703.5, -103_704},
+		{Currency{"USD", 2}, 1.005, 101},
+		{Currency{"USD", 2}, -1.005, -101},
+		{Currency{"USD", 2}, -109.689161, -10_969},
+		{Currency{"BHD", 3}, -109.689161, -109_689},
+		{Currency{"USD", 2}, math.NaN(), 0},
+	}
+	for _, testCase := range testCases {
+		actual := testCase.currency.Money(testCase.amount, RoundHalfAwayFromZero)
+		assert.Equal(t, testCase.expected, actual, testCase)
+	}
+}
+
+func TestCurrencyRound(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, -109.69, Currency{"USD", 2}.Round(-109.689161, RoundHalfAwayFromZero))
+		assert.Equal(t, -109.689, Currency{"BHD", 3}.Round(-109.689161, RoundHalfAwayFromZero))
+		assert.Equal(t, -110.0, Currency{"JPY", 0}.Round(-109.689161, RoundHalfAwayFromZero))
+	})
+
+	t.Run("honours the rounding mode", func(t *testing.T) {
+		assert.Equal(t, -109.68, Currency{"USD", 2}.Round(-109.689161, RoundTruncate))
+		assert.Equal(t, -109.68, PmtCurrency(Currency{"USD", 2}, RoundTruncate, 0.01, 12, 1_234.56, 0, false))
+	})
+}
+
+func TestPmtCurrency(t *testing.T) {
+	usd := Currency{"USD", 2}
+	jpy := Currency{"JPY", 0}
+	assert.Equal(t, -109.69, PmtCurrency(usd, RoundHalfAwayFromZero, 0.01, 12, 1_234.56, 0, false))
+	assert.Equal(t, float64(Pmt(0.3, 36, 100_000, 0, false)), PmtCurrency(jpy, RoundHalfAwayFromZero, 0.3, 36, 100_000, 0, false))
+}
+
+func TestIpmtCurrency(t *testing.T) {
+	usd := Currency{"USD", 2}
+	expected := usd.Round(IpmtF64(0.01, 3, 12, 1_234.56, 0, false), RoundHalfAwayFromZero)
+	assert.Equal(t, expected, IpmtCurrency(usd, RoundHalfAwayFromZero, 0.01, 3, 12, 1_234.56, 0, false))
+	assert.Equal(t, -10.39, expected)
+}
+
+func TestFvCurrency(t *testing.T) {
+	bhd := Currency{"BHD", 3}
+	assert.Equal(t, 1_271.421, FvCurrency(bhd, RoundHalfAwayFromZero, 0.01, 12, -100.25, 0, false))
+}
+
+func TestPpmtCurrency(t *testing.T) {
+	usd := Currency{"USD", 2}
+	assert.Equal(t, -99.30, PpmtCurrency(usd, RoundHalfAwayFromZero, 0.01, 3, 12, 1_234.56, 0, false))
+}
+
+func TestCumipmtCurrency(t *testing.T) {
+	usd := Currency{"USD", 2}
+	assert.Equal(t, -5_378.58, CumipmtCurrency(usd, RoundHalfAwayFromZero, 0.1, 36, 8_000, 6, 12, false))
+}
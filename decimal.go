@@ -0,0 +1,90 @@
+package xlsxfin
+
+import "math/big"
+
+// Money is an amount in the smallest unit of a currency, such as yen or
+// cents.
+//
+// The Rat functions below compute with math/big.Rat and are exact: for an
+// integral number of periods every result is a rational number and no
+// digits are lost, so for example the IpmtRat values of periods start..end
+// always add up to CumipmtRat exactly. The Money functions evaluate the Rat
+// function and then round the exact result once to a whole unit, following
+// the same RoundingMode as the int functions such as Pmt.
+type Money int64
+
+type ratDecimal struct {
+	r *big.Rat
+}
+
+func (x ratDecimal) Add(y ratDecimal) ratDecimal {
+	return ratDecimal{new(big.Rat).Add(x.r, y.r)}
+}
+
+func (x ratDecimal) Sub(y ratDecimal) ratDecimal {
+	return ratDecimal{new(big.Rat).Sub(x.r, y.r)}
+}
+
+func (x ratDecimal) Mul(y ratDecimal) ratDecimal {
+	return ratDecimal{new(big.Rat).Mul(x.r, y.r)}
+}
+
+func (x ratDecimal) Div(y ratDecimal) ratDecimal {
+	return ratDecimal{new(big.Rat).Quo(x.r, y.r)}
+}
+
+func (x ratDecimal) Sign() int {
+	return x.r.Sign()
+}
+
+func (ratDecimal) FromInt(n int64) ratDecimal {
+	return ratDecimal{new(big.Rat).SetInt64(n)}
+}
+
+func roundRat(x *big.Rat, mode RoundingMode) Money {
+	return Money(mode.roundRat(x).Int64())
+}
+
+func moneyRat(m Money) *big.Rat {
+	return new(big.Rat).SetInt64(int64(m))
+}
+
+func PmtRat(rate *big.Rat, nper int, pv *big.Rat, fv *big.Rat, paymentFlag bool) *big.Rat {
+	return PmtDecimal(ratDecimal{rate}, nper, ratDecimal{pv}, ratDecimal{fv}, timingOf(paymentFlag)).r
+}
+
+func PmtMoney(mode RoundingMode, rate *big.Rat, nper int, pv Money, fv Money, paymentFlag bool) Money {
+	return roundRat(PmtRat(rate, nper, moneyRat(pv), moneyRat(fv), paymentFlag), mode)
+}
+
+func IpmtRat(rate *big.Rat, per int, nper int, pv *big.Rat, fv *big.Rat, paymentFlag bool) *big.Rat {
+	return IpmtDecimal(ratDecimal{rate}, per, nper, ratDecimal{pv}, ratDecimal{fv}, timingOf(paymentFlag)).r
+}
+
+func IpmtMoney(mode RoundingMode, rate *big.Rat, per int, nper int, pv Money, fv Money, paymentFlag bool) Money {
+	return roundRat(IpmtRat(rate, per, nper, moneyRat(pv), moneyRat(fv), paymentFlag), mode)
+}
+
+func FvRat(rate *big.Rat, nper int, pmt *big.Rat, pv *big.Rat, paymentFlag bool) *big.Rat {
+	return FvDecimal(ratDecimal{rate}, nper, ratDecimal{pmt}, ratDecimal{pv}, timingOf(paymentFlag)).r
+}
+
+func FvMoney(mode RoundingMode, rate *big.Rat, nper int, pmt Money, pv Money, paymentFlag bool) Money {
+	return roundRat(FvRat(rate, nper, moneyRat(pmt), moneyRat(pv), paymentFlag), mode)
+}
+
+func PpmtRat(rate *big.Rat, per int, nper int, pv *big.Rat, fv *big.Rat, paymentFlag bool) *big.Rat {
+	return PpmtDecimal(ratDecimal{rate}, per, nper, ratDecimal{pv}, ratDecimal{fv}, timingOf(paymentFlag)).r
+}
+
+func PpmtMoney(mode RoundingMode, rate *big.Rat, per int, nper int, pv Money, fv Money, paymentFlag bool) Money {
+	return roundRat(PpmtRat(rate, per, nper, moneyRat(pv), moneyRat(fv), paymentFlag), mode)
+}
+
+func CumipmtRat(rate *big.Rat, nper int, pv *big.Rat, start int, end int, paymentFlag bool) *big.Rat {
+	return CumipmtDecimal(ratDecimal{rate}, nper, ratDecimal{pv}, start, end, timingOf(paymentFlag)).r
+}
+
+func CumipmtMoney(mode RoundingMode, rate *big.Rat, nper int, pv Money, start int, end int, paymentFlag bool) Money {
+	return roundRat(CumipmtRat(rate, nper, moneyRat(pv), start, end, paymentFlag), mode)
+}
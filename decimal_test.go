@@ -0,0 +1,166 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePmtMoney() {
+	v := PmtMoney(RoundHalfAwayFromZero, big.NewRat(8, 1200), 10, 1_000_000, 0, false)
+	fmt.Println(v)
+	// Output: -103703
+}
+
+func TestPmtRat(t *testing.T) {
+	t.Run("nper is 0", func(t *testing.T) {
+		actual := PmtRat(big.NewRat(3, 10), 0, big.NewRat(100_000, 1), new(big.Rat), false)
+		assert.Equal(t, 0, actual.Sign())
+	})
+
+	t.Run("rate is 0", func(t *testing.T) {
+		actual := PmtRat(new(big.Rat), 36, big.NewRat(100_000, 1), big.NewRat(1_000, 1), false)
+		assert.Equal(t, "-25250/9", actual.RatString())
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		actual := PmtRat(big.NewRat(1, 10), 2, big.NewRat(210, 1), new(big.Rat), false)
+		assert.Equal(t, "-121", actual.RatString())
+	})
+
+	t.Run("matches PmtFloat64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			expected := PmtFloat64(0.3, 36, 100_000, 1_000, paymentFlag)
+			actual, _ := PmtRat(big.NewRat(3, 10), 36, big.NewRat(100_000, 1), big.NewRat(1_000, 1), paymentFlag).Float64()
+			assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+		}
+	})
+}
+
+func TestPmtMoney(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		expected := Pmt(0.3, 36, 100_000, 1_000, paymentFlag)
+		actual := PmtMoney(RoundHalfAwayFromZero, big.NewRat(3, 10), 36, 100_000, 1_000, paymentFlag)
+		assert.Equal(t, Money(expected), actual, paymentFlag)
+	}
+}
+
+func TestIpmtRat(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, 0, IpmtRat(big.NewRat(3, 10), 3, 0, big.NewRat(100_000, 1), new(big.Rat), false).Sign())
+		assert.Equal(t, 0, IpmtRat(big.NewRat(3, 10), 0, 36, big.NewRat(100_000, 1), new(big.Rat), false).Sign())
+		assert.Equal(t, 0, IpmtRat(big.NewRat(-1, 1), 3, 36, big.NewRat(100_000, 1), new(big.Rat), false).Sign())
+	})
+
+	t.Run("matches IpmtFloat64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			for _, rate := range []float64{0.1, 0.6} {
+				for _, per := range []int{1, 2} {
+					expected := IpmtFloat64(rate, per, 36, 800_000, 1_000, paymentFlag)
+					actual, _ := IpmtRat(new(big.Rat).SetFloat64(rate), per, 36, big.NewRat(800_000, 1), big.NewRat(1_000, 1), paymentFlag).Float64()
+					assert.InDelta(t, expected, actual, DELTA, paymentFlag, per)
+				}
+			}
+		}
+	})
+}
+
+func TestIpmtMoney(t *testing.T) {
+	actual := IpmtMoney(RoundHalfAwayFromZero, big.NewRat(1, 10), 2, 36, 800_000, 0, false)
+	assert.Equal(t, Money(-79_733), actual)
+}
+
+func TestFvRat(t *testing.T) {
+	t.Run("rate is 0", func(t *testing.T) {
+		actual := FvRat(new(big.Rat), 12, big.NewRat(-1_000, 1), big.NewRat(10_000, 1), false)
+		assert.Equal(t, "2000", actual.RatString())
+	})
+
+	t.Run("matches FvFloat64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			expected := FvFloat64(0.1, 12, 10_000.0, 1_000, paymentFlag)
+			actual, _ := FvRat(big.NewRat(1, 10), 12, big.NewRat(10_000, 1), big.NewRat(1_000, 1), paymentFlag).Float64()
+			assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+		}
+	})
+}
+
+func TestFvMoney(t *testing.T) {
+	actual := FvMoney(RoundHalfAwayFromZero, big.NewRat(1, 10), 12, 10_000, 0, false)
+	assert.Equal(t, Money(-213_843), actual)
+}
+
+func TestPpmtRat(t *testing.T) {
+	t.Run("per is out of range", func(t *testing.T) {
+		assert.Equal(t, 0, PpmtRat(big.NewRat(1, 10), 0, 10, big.NewRat(800_000, 1), new(big.Rat), false).Sign())
+		assert.Equal(t, 0, PpmtRat(big.NewRat(1, 10), 11, 10, big.NewRat(800_000, 1), new(big.Rat), false).Sign())
+	})
+
+	t.Run("principal adds up to pv", func(t *testing.T) {
+		rate := big.NewRat(8, 1200)
+		pv := big.NewRat(1_000_000, 1)
+		total := new(big.Rat)
+		for per := 1; per <= 10; per++ {
+			total.Add(total, PpmtRat(rate, per, 10, pv, new(big.Rat), false))
+		}
+		assert.Equal(t, "-1000000", total.RatString())
+	})
+}
+
+func TestPpmtMoney(t *testing.T) {
+	actual := PpmtMoney(RoundHalfAwayFromZero, big.NewRat(1, 10), 12, 36, 800_000, 0, false)
+	assert.Equal(t, Money(-7_631), actual)
+}
+
+func TestCumipmtRat(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, 0, CumipmtRat(new(big.Rat), 36, big.NewRat(800_000, 1), 6, 12, false).Sign())
+		assert.Equal(t, 0, CumipmtRat(big.NewRat(1, 10), 36, big.NewRat(800_000, 1), 10, 9, false).Sign())
+	})
+
+	t.Run("equals the sum of IpmtRat", func(t *testing.T) {
+		pv := big.NewRat(1_000_000, 1)
+		for _, paymentFlag := range []bool{false, true} {
+			for _, rate := range []*big.Rat{big.NewRat(8, 1200), big.NewRat(-5, 1000)} {
+				for _, period := range [][2]int{{1, 1}, {1, 10}, {2, 2}, {3, 7}} {
+					expected := new(big.Rat)
+					for per := period[0]; per <= period[1]; per++ {
+						expected.Add(expected, IpmtRat(rate, per, 10, pv, new(big.Rat), paymentFlag))
+					}
+					actual := CumipmtRat(rate, 10, pv, period[0], period[1], paymentFlag)
+					assert.Equal(t, expected.RatString(), actual.RatString(), paymentFlag, rate, period)
+				}
+			}
+		}
+	})
+
+	t.Run("matches CumipmtFloat64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			for _, rate := range []*big.Rat{big.NewRat(1, 10), big.NewRat(-5, 1000)} {
+				f, _ := rate.Float64()
+				expected := CumipmtFloat64(f, 36, 800_000, 6, 12, paymentFlag)
+				actual, _ := CumipmtRat(rate, 36, big.NewRat(800_000, 1), 6, 12, paymentFlag).Float64()
+				assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+			}
+		}
+	})
+}
+
+func BenchmarkCumipmtRat(b *testing.B) {
+	rate := big.NewRat(35, 12_000)
+	pv := big.NewRat(30_000_000, 1)
+	for _, nper := range []int{12, 420, 10_950} {
+		b.Run(fmt.Sprintf("nper=%d", nper), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				CumipmtRat(rate, nper, pv, 1, nper, false)
+			}
+		})
+	}
+}
+
+func TestCumipmtMoney(t *testing.T) {
+	actual := CumipmtMoney(RoundHalfAwayFromZero, big.NewRat(1, 10), 36, 800_000, 6, 12, true)
+	assert.Equal(t, Money(-488_962), actual)
+}
@@ -0,0 +1,88 @@
+package xlsxfin
+
+// EqualPrincipalSchedule returns the schedule of the loan repaid in equal
+// principal installments instead of level payments, or nil if the loan
+// fails Validate. Every period repays the same principal, so the payments
+// decline with the balance. Interest follows the same rules as Schedule:
+// -Rate times the opening balance, except the first period under
+// BeginningOfPeriod which has none, and the balance closes at -Fv, or at
+// -Fv/(1+Rate) under BeginningOfPeriod.
+func (l Loan) EqualPrincipalSchedule() Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	last := l.lastBalance()
+	principal := (last - l.Pv) / float64(l.Nper)
+
+	schedule := make(Schedule, l.Nper)
+	opening := l.Pv
+	cumulativeInterest := 0.0
+	for i := range schedule {
+		per := i + 1
+		interest := 0.0
+		if !l.due() || per > 1 {
+			interest = -l.Rate * opening
+		}
+		cumulativeInterest += interest
+
+		// Interpolated rather than summed, so the last balance is exact.
+		closing := last + (l.Pv-last)*(float64(l.Nper-per)/float64(l.Nper))
+
+		schedule[i] = Installment{
+			Period:             per,
+			Payment:            principal + interest,
+			Interest:           interest,
+			Principal:          principal,
+			OpeningBalance:     opening,
+			ClosingBalance:     closing,
+			CumulativeInterest: cumulativeInterest,
+		}
+		opening = closing
+	}
+	return schedule
+}
+
+// RoundedEqualPrincipalSchedule is EqualPrincipalSchedule with every amount
+// rounded to the currency's minor units with mode, like RoundedSchedule.
+// The principal is rounded once and each period's interest is rounded from
+// the rounded balance; the installment chosen by trueUp repays the
+// principal left over by rounding, so the balance closes exactly. It
+// returns nil if the loan fails Validate or if the principal left over
+// would cancel that installment's principal or reverse its sign.
+func (l Loan) RoundedEqualPrincipalSchedule(currency Currency, mode RoundingMode, trueUp TrueUp) Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	pv := currency.Money(l.Pv, mode)
+	last := currency.Money(l.lastBalance(), mode)
+	principal := currency.Money((l.lastBalance()-l.Pv)/float64(l.Nper), mode)
+	remainder := last - pv - principal*Money(l.Nper)
+
+	if reversed(principal, principal+remainder) {
+		return nil
+	}
+
+	adjusted := l.Nper - 1
+	if trueUp == FirstAndFinalTrueUp {
+		adjusted = 0
+	}
+
+	rows := make([]roundedInstallment, l.Nper)
+	balance := pv
+	for i := range rows {
+		row := roundedInstallment{principal: principal, opening: balance}
+		if i == adjusted {
+			row.principal += remainder
+		}
+		if !l.due() || i > 0 {
+			row.interest = currency.Money(-l.Rate*currency.Float64(balance), mode)
+		}
+		row.payment = row.principal + row.interest
+		row.closing = row.opening + row.principal
+		rows[i] = row
+		balance = row.closing
+	}
+	return newRoundedSchedule(currency, rows)
+}
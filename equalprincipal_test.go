@@ -0,0 +1,110 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLoan_EqualPrincipalSchedule() {
+	loan := Loan{Rate: 0.08 / 12, Nper: 3, Pv: 1_000_000}
+	for _, installment := range loan.EqualPrincipalSchedule() {
+		fmt.Printf("%d %.2f %.2f %.2f %.2f\n", installment.Period, installment.Payment, installment.Interest, installment.Principal, installment.ClosingBalance)
+	}
+
+	level, equal := loan.Schedule().Totals(), loan.EqualPrincipalSchedule().Totals()
+	fmt.Printf("%.2f %.2f\n", level.Interest, equal.Interest)
+	// Output:
+	// 1 -340000.00 -6666.67 -333333.33 666666.67
+	// 2 -337777.78 -4444.44 -333333.33 333333.33
+	// 3 -335555.56 -2222.22 -333333.33 0.00
+	// -13362.86 -13333.33
+}
+
+func TestLoanEqualPrincipalSchedule(t *testing.T) {
+	assert.Nil(t, Loan{Rate: 0.1, Nper: 0, Pv: 1_000}.EqualPrincipalSchedule())
+
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, rate := range []float64{0, -0.005, 0.035 / 12} {
+			for _, fv := range []float64{0, 100_000} {
+				loan := Loan{Rate: rate, Nper: 36, Pv: 800_000, Fv: fv, Timing: timing}
+				args := fmt.Sprint(loan)
+				schedule := loan.EqualPrincipalSchedule()
+				assert.Len(t, schedule, 36, args)
+
+				for i, installment := range schedule {
+					assert.Equal(t, i+1, installment.Period, args)
+					assert.InDelta(t, (loan.lastBalance()-loan.Pv)/36, installment.Principal, DELTA, args)
+					if timing == BeginningOfPeriod && i == 0 {
+						assert.Equal(t, 0.0, installment.Interest, args)
+					} else {
+						assert.InDelta(t, -rate*installment.OpeningBalance, installment.Interest, DELTA, args)
+					}
+					assert.InDelta(t, installment.Principal+installment.Interest, installment.Payment, DELTA, args)
+					assert.InDelta(t, installment.OpeningBalance+installment.Principal, installment.ClosingBalance, DELTA, args)
+					if i > 0 {
+						assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, args)
+					}
+				}
+				assert.Equal(t, loan.lastBalance(), schedule[35].ClosingBalance, args)
+
+				totals := schedule.Totals()
+				assert.InDelta(t, loan.lastBalance()-loan.Pv, totals.Principal, DELTA, args)
+				assert.InDelta(t, schedule.TotalInterest(), totals.Interest, DELTA, args)
+				assert.InDelta(t, totals.Principal+totals.Interest, totals.Payment, DELTA, args)
+			}
+		}
+	}
+
+	t.Run("less interest than level payments", func(t *testing.T) {
+		loan := Loan{Rate: 0.035 / 12, Nper: 420, Pv: 30_000_000}
+		level, equal := loan.Schedule().Totals(), loan.EqualPrincipalSchedule().Totals()
+		assert.InDelta(t, level.Principal, equal.Principal, DELTA)
+		assert.Greater(t, equal.Interest, level.Interest)
+	})
+}
+
+func TestLoanRoundedEqualPrincipalSchedule(t *testing.T) {
+	jpy, _ := LookupCurrency("JPY")
+	usd, _ := LookupCurrency("USD")
+
+	assert.Nil(t, Loan{Rate: -1, Nper: 12, Pv: 1_000}.RoundedEqualPrincipalSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp))
+	// -2 yen a month repays 840 yen, so the last installment would repay -140.
+	assert.Nil(t, Loan{Rate: 0.01 / 12, Nper: 420, Pv: 700}.RoundedEqualPrincipalSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp))
+
+	loans := []Loan{
+		{Rate: 0.035 / 12, Nper: 420, Pv: 30_000_000},
+		{Rate: 0.1, Nper: 36, Pv: 800_000, Timing: BeginningOfPeriod},
+		{Rate: 0.01 / 12, Nper: 35, Pv: 1_000_000, Fv: 100_000},
+	}
+	for _, currency := range []Currency{jpy, usd} {
+		for _, mode := range []RoundingMode{RoundHalfAwayFromZero, RoundFloor, RoundCeiling} {
+			for _, trueUp := range []TrueUp{FinalTrueUp, FirstAndFinalTrueUp} {
+				for _, loan := range loans {
+					args := fmt.Sprint(currency.Code, mode, trueUp, loan)
+					schedule := loan.RoundedEqualPrincipalSchedule(currency, mode, trueUp)
+					exact := loan.EqualPrincipalSchedule()
+
+					adjusted := loan.Nper - 1
+					if trueUp == FirstAndFinalTrueUp {
+						adjusted = 0
+					}
+					principal := currency.Money(exact[0].Principal, mode)
+					for i, installment := range schedule {
+						assert.Equal(t, currency.Money(installment.Payment, RoundHalfAwayFromZero), currency.Money(installment.Interest, RoundHalfAwayFromZero)+currency.Money(installment.Principal, RoundHalfAwayFromZero), "%v %d", args, i)
+						assert.Equal(t, currency.Money(installment.ClosingBalance, RoundHalfAwayFromZero), currency.Money(installment.OpeningBalance, RoundHalfAwayFromZero)+currency.Money(installment.Principal, RoundHalfAwayFromZero), "%v %d", args, i)
+						if i != adjusted {
+							assert.Equal(t, principal, currency.Money(installment.Principal, RoundHalfAwayFromZero), "%v %d", args, i)
+						}
+						// Rounding moves the balance by up to a unit a period, or
+						// by all of them at once with FirstAndFinalTrueUp, and the interest
+						// by Rate times that.
+						assert.InDelta(t, exact[i].Interest, installment.Interest, currency.Float64(1)*(2+loan.Rate*float64(loan.Nper)), "%v %d", args, i)
+					}
+					assert.Equal(t, currency.Money(loan.lastBalance(), mode), currency.Money(schedule[loan.Nper-1].ClosingBalance, RoundHalfAwayFromZero), args)
+				}
+			}
+		}
+	}
+}
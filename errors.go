@@ -0,0 +1,270 @@
+package xlsxfin
+
+import (
+	"errors"
+	"math"
+)
+
+// Errors returned by the E-suffixed functions. Each corresponds to the
+// Excel error value the same arguments would produce in a worksheet.
+var (
+	ErrNum   = errors.New("#NUM!")
+	ErrValue = errors.New("#VALUE!")
+	ErrDiv0  = errors.New("#DIV/0!")
+)
+
+func checkArgs(values ...float64) error {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrValue
+		}
+	}
+	return nil
+}
+
+func checkResult(v float64) (float64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0.0, ErrNum
+	}
+	return v, nil
+}
+
+func (mode RoundingMode) roundE(v float64, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	return mode.roundInt(v), nil
+}
+
+func PmtF64E(rate float64, nper int, pv float64, fv float64, paymentFlag bool) (float64, error) {
+	if err := checkArgs(rate, pv, fv); err != nil {
+		return 0.0, err
+	}
+	if rate <= -1 || nper == 0 {
+		return 0.0, ErrNum
+	}
+	return checkResult(PmtF64(rate, nper, pv, fv, paymentFlag))
+}
+
+func PmtFloat64E(rate float64, nper int, pv int, fv int, paymentFlag bool) (float64, error) {
+	return PmtF64E(rate, nper, float64(pv), float64(fv), paymentFlag)
+}
+
+func PmtE(rate float64, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return PmtRoundE(RoundHalfAwayFromZero, rate, nper, pv, fv, paymentFlag)
+}
+
+func PmtRoundE(mode RoundingMode, rate float64, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return mode.roundE(PmtFloat64E(rate, nper, pv, fv, paymentFlag))
+}
+
+func IpmtF64E(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) (float64, error) {
+	if err := checkArgs(rate, pv, fv); err != nil {
+		return 0.0, err
+	}
+	if rate <= -1 || nper <= 0 || per < 1 || per > nper {
+		return 0.0, ErrNum
+	}
+	return checkResult(IpmtF64(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func IpmtFloat64E(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (float64, error) {
+	return IpmtF64E(rate, per, nper, float64(pv), float64(fv), paymentFlag)
+}
+
+func IpmtE(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return IpmtRoundE(RoundHalfAwayFromZero, rate, per, nper, pv, fv, paymentFlag)
+}
+
+func IpmtRoundE(mode RoundingMode, rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return mode.roundE(IpmtFloat64E(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func IspmtF64E(rate float64, per int, nper int, pv float64) (float64, error) {
+	if err := checkArgs(rate, pv); err != nil {
+		return 0.0, err
+	}
+	if nper == 0 {
+		return 0.0, ErrDiv0
+	}
+	return checkResult(IspmtF64(rate, per, nper, pv))
+}
+
+func IspmtFloat64E(rate float64, per int, nper int, pv int) (float64, error) {
+	return IspmtF64E(rate, per, nper, float64(pv))
+}
+
+func IspmtE(rate float64, per int, nper int, pv int) (int, error) {
+	return IspmtRoundE(RoundHalfAwayFromZero, rate, per, nper, pv)
+}
+
+func IspmtRoundE(mode RoundingMode, rate float64, per int, nper int, pv int) (int, error) {
+	return mode.roundE(IspmtFloat64E(rate, per, nper, pv))
+}
+
+func FvF64E(rate float64, nper int, pmt float64, pv float64, paymentFlag bool) (float64, error) {
+	if err := checkArgs(rate, pmt, pv); err != nil {
+		return 0.0, err
+	}
+	if rate <= -1 {
+		return 0.0, ErrNum
+	}
+	return checkResult(FvF64(rate, nper, pmt, pv, paymentFlag))
+}
+
+func FvFloat64E(rate float64, nper int, pmt float64, pv int, paymentFlag bool) (float64, error) {
+	return FvF64E(rate, nper, pmt, float64(pv), paymentFlag)
+}
+
+func FvE(rate float64, nper int, pmt float64, pv int, paymentFlag bool) (int, error) {
+	return FvRoundE(RoundHalfAwayFromZero, rate, nper, pmt, pv, paymentFlag)
+}
+
+func FvRoundE(mode RoundingMode, rate float64, nper int, pmt float64, pv int, paymentFlag bool) (int, error) {
+	return mode.roundE(FvFloat64E(rate, nper, pmt, pv, paymentFlag))
+}
+
+func FvscheduleFloat64E(principal float64, rates []float64) (float64, error) {
+	if err := checkArgs(principal); err != nil {
+		return 0.0, err
+	}
+	if err := checkArgs(rates...); err != nil {
+		return 0.0, err
+	}
+	return checkResult(FvscheduleFloat64(principal, rates))
+}
+
+func FvscheduleE(principal float64, rates []float64) (int, error) {
+	return FvscheduleRoundE(RoundHalfAwayFromZero, principal, rates)
+}
+
+func FvscheduleRoundE(mode RoundingMode, principal float64, rates []float64) (int, error) {
+	return mode.roundE(FvscheduleFloat64E(principal, rates))
+}
+
+func FvRatesF64E(rates []float64, pmt float64, pv float64, paymentFlag bool) (float64, error) {
+	if err := checkArgs(pmt, pv); err != nil {
+		return 0.0, err
+	}
+	if err := checkArgs(rates...); err != nil {
+		return 0.0, err
+	}
+	return checkResult(FvRatesF64(rates, pmt, pv, paymentFlag))
+}
+
+func FvRatesFloat64E(rates []float64, pmt float64, pv int, paymentFlag bool) (float64, error) {
+	return FvRatesF64E(rates, pmt, float64(pv), paymentFlag)
+}
+
+func FvRatesE(rates []float64, pmt float64, pv int, paymentFlag bool) (int, error) {
+	return FvRatesRoundE(RoundHalfAwayFromZero, rates, pmt, pv, paymentFlag)
+}
+
+func FvRatesRoundE(mode RoundingMode, rates []float64, pmt float64, pv int, paymentFlag bool) (int, error) {
+	return mode.roundE(FvRatesFloat64E(rates, pmt, pv, paymentFlag))
+}
+
+func PdurationF64E(rate float64, pv float64, fv float64) (float64, error) {
+	if err := checkArgs(rate, pv, fv); err != nil {
+		return 0.0, err
+	}
+	if rate <= 0.0 || pv <= 0 || fv <= 0 {
+		return 0.0, ErrNum
+	}
+	return checkResult(PdurationF64(rate, pv, fv))
+}
+
+func PdurationE(rate float64, pv int, fv int) (float64, error) {
+	return PdurationF64E(rate, float64(pv), float64(fv))
+}
+
+func RriF64E(nper int, pv float64, fv float64) (float64, error) {
+	if err := checkArgs(pv, fv); err != nil {
+		return 0.0, err
+	}
+	if nper <= 0 || pv == 0 {
+		return 0.0, ErrNum
+	}
+	return checkResult(math.Pow(fv/pv, 1.0/float64(nper)) - 1)
+}
+
+func RriE(nper int, pv int, fv int) (float64, error) {
+	return RriF64E(nper, float64(pv), float64(fv))
+}
+
+func PpmtF64E(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) (float64, error) {
+	if _, err := IpmtF64E(rate, per, nper, pv, fv, paymentFlag); err != nil {
+		return 0.0, err
+	}
+	return checkResult(PpmtF64(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func PpmtFloat64E(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (float64, error) {
+	return PpmtF64E(rate, per, nper, float64(pv), float64(fv), paymentFlag)
+}
+
+func PpmtE(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return PpmtRoundE(RoundHalfAwayFromZero, rate, per, nper, pv, fv, paymentFlag)
+}
+
+func PpmtRoundE(mode RoundingMode, rate float64, per int, nper int, pv int, fv int, paymentFlag bool) (int, error) {
+	return mode.roundE(PpmtFloat64E(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func CumipmtF64E(rate float64, nper int, pv float64, start int, end int, paymentFlag bool) (float64, error) {
+	if err := checkArgs(rate, pv); err != nil {
+		return 0.0, err
+	}
+	if rate <= -1 || nper <= 0 || pv <= 0 {
+		return 0.0, ErrNum
+	}
+	if start < 1 || end < 1 || start > end || end > nper {
+		return 0.0, ErrNum
+	}
+	return checkResult(CumipmtF64(rate, nper, pv, start, end, paymentFlag))
+}
+
+func CumipmtFloat64E(rate float64, nper int, pv int, start int, end int, paymentFlag bool) (float64, error) {
+	return CumipmtF64E(rate, nper, float64(pv), start, end, paymentFlag)
+}
+
+func CumipmtE(rate float64, nper int, pv int, start int, end int, paymentFlag bool) (int, error) {
+	return CumipmtRoundE(RoundHalfAwayFromZero, rate, nper, pv, start, end, paymentFlag)
+}
+
+func CumipmtRoundE(mode RoundingMode, rate float64, nper int, pv int, start int, end int, paymentFlag bool) (int, error) {
+	return mode.roundE(CumipmtFloat64E(rate, nper, pv, start, end, paymentFlag))
+}
+
+func checkFraction(fraction float64) error {
+	if err := checkArgs(fraction); err != nil {
+		return err
+	}
+	if fraction < 0 {
+		return ErrNum
+	}
+	if fraction < 1 {
+		return ErrDiv0
+	}
+	return nil
+}
+
+func DollarDeE(fractionalDollar float64, fraction float64) (float64, error) {
+	if err := checkArgs(fractionalDollar); err != nil {
+		return 0.0, err
+	}
+	if err := checkFraction(fraction); err != nil {
+		return 0.0, err
+	}
+	return DollarDe(fractionalDollar, fraction), nil
+}
+
+func DollarFrE(decimalDollar float64, fraction float64) (float64, error) {
+	if err := checkArgs(decimalDollar); err != nil {
+		return 0.0, err
+	}
+	if err := checkFraction(fraction); err != nil {
+		return 0.0, err
+	}
+	return DollarFr(decimalDollar, fraction), nil
+}
@@ -0,0 +1,307 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePmtE() {
+	_, err := PmtE(0.3, 0, 100_000, 0, false)
+	fmt.Println(err)
+	// Output: #NUM!
+}
+
+func TestPmtFloat64E(t *testing.T) {
+	t.Run("nper is 0", func(t *testing.T) {
+		_, err := PmtFloat64E(0.3, 0, 100_000, 0, false)
+		assert.ErrorIs(t, err, ErrNum)
+	})
+
+	t.Run("rate <= -1", func(t *testing.T) {
+		_, err := PmtFloat64E(-1, 36, 100_000, 0, false)
+		assert.ErrorIs(t, err, ErrNum)
+	})
+
+	t.Run("rate is NaN", func(t *testing.T) {
+		_, err := PmtFloat64E(math.NaN(), 36, 100_000, 0, false)
+		assert.ErrorIs(t, err, ErrValue)
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		actual, err := PmtFloat64E(0.3, 36, 100_000, 0, false)
+		assert.NoError(t, err)
+		assert.InDelta(t, -30_002.372438, actual, DELTA)
+	})
+}
+
+func TestPmtF64E(t *testing.T) {
+	_, err := PmtF64E(0.01, 12, math.NaN(), 0, false)
+	assert.ErrorIs(t, err, ErrValue)
+
+	actual, err := PmtF64E(0.01, 12, 1_234.56, 0, false)
+	assert.NoError(t, err)
+	assert.InDelta(t, -109.689161, actual, DELTA)
+}
+
+func TestPmtE(t *testing.T) {
+	actual, err := PmtE(0.3, 36, 100_000, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -30_002, actual)
+}
+
+func TestIpmtFloat64E(t *testing.T) {
+	type testArgs struct {
+		rate        float64
+		per         int
+		nper        int
+		pv          int
+		fv          int
+		paymentFlag bool
+	}
+
+	type testData struct {
+		args     testArgs
+		expected error
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{0.3, 3, 0, 100_000, 0, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.3, 0, 36, 100_000, 0, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.3, 37, 36, 100_000, 0, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{-1.0, 24, 36, 100_000, 0, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{math.Inf(1), 24, 36, 100_000, 0, false},
+				expected: ErrValue,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			_, err := IpmtFloat64E(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.ErrorIs(t, err, testCase.expected, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		actual, err := IpmtFloat64E(0.1, 2, 36, 800_000, 0, false)
+		assert.NoError(t, err)
+		assert.InDelta(t, -79_732.554895, actual, DELTA)
+
+		actual, err = IpmtFloat64E(-0.005, 2, 36, 800_000, 0, false)
+		assert.NoError(t, err)
+		assert.InDelta(t, 3_878.866309, actual, DELTA)
+	})
+}
+
+func TestIpmtE(t *testing.T) {
+	actual, err := IpmtE(0.1, 2, 36, 800_000, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -79_733, actual)
+
+	_, err = IpmtE(0.1, 0, 36, 800_000, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+}
+
+func TestIspmtE(t *testing.T) {
+	_, err := IspmtFloat64E(0.1, 1, 0, 800_000)
+	assert.ErrorIs(t, err, ErrDiv0)
+
+	actual, err := IspmtE(0.1, 1, 3, 8_000_000)
+	assert.NoError(t, err)
+	assert.Equal(t, -533_333, actual)
+}
+
+func TestFvE(t *testing.T) {
+	_, err := FvFloat64E(0.1, 12, math.NaN(), 0, false)
+	assert.ErrorIs(t, err, ErrValue)
+
+	_, err = FvFloat64E(math.MaxFloat64, 12, 10_000.0, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = FvFloat64E(-1.5, 12, 10_000.0, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+
+	actual, err := FvE(0.1, 12, 10_000.0, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -213_843, actual)
+}
+
+func TestFvscheduleE(t *testing.T) {
+	_, err := FvscheduleFloat64E(10_000, []float64{0.01, math.NaN()})
+	assert.ErrorIs(t, err, ErrValue)
+
+	actual, err := FvscheduleE(10_000, []float64{0.01, 0.02, 0.03})
+	assert.NoError(t, err)
+	assert.Equal(t, 10_611, actual)
+}
+
+func TestFvRatesE(t *testing.T) {
+	_, err := FvRatesFloat64E([]float64{0.01, math.Inf(-1)}, -1_000, 10_000, false)
+	assert.ErrorIs(t, err, ErrValue)
+
+	actual, err := FvRatesE([]float64{0.01, 0.02, 0.03}, -1_000, 10_000, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -7_530, actual)
+}
+
+func TestPdurationE(t *testing.T) {
+	_, err := PdurationE(0.0, 2_000, 2_200)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = PdurationE(0.025, 2_000, 0)
+	assert.ErrorIs(t, err, ErrNum)
+
+	actual, err := PdurationE(0.025, 2_000, 2_200)
+	assert.NoError(t, err)
+	assert.InDelta(t, 3.859866, actual, DELTA)
+}
+
+func TestRriE(t *testing.T) {
+	_, err := RriE(0, 10_000, 30_000)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = RriE(96, 0, 30_000)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = RriE(2, 10_000, -30_000)
+	assert.ErrorIs(t, err, ErrNum)
+
+	actual, err := RriE(96, 10_000, 30_000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.011510, actual, DELTA)
+}
+
+func TestPpmtE(t *testing.T) {
+	_, err := PpmtFloat64E(0.1, 0, 10, 800_000, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = PpmtFloat64E(0.1, 11, 10, 800_000, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+
+	actual, err := PpmtE(0.1, 12, 36, 800_000, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -7_631, actual)
+}
+
+func TestCumipmtFloat64E(t *testing.T) {
+	type testArgs struct {
+		rate        float64
+		nper        int
+		pv          int
+		start       int
+		end         int
+		paymentFlag bool
+	}
+
+	type testData struct {
+		args     testArgs
+		expected error
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{-1.0, 36, 800_000, 6, 12, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.1, 0, 800_000, 6, 12, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.1, 36, 0, 6, 12, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.1, 36, 800_000, 0, 12, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.1, 36, 800_000, 10, 9, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{0.1, 36, 800_000, 6, 37, false},
+				expected: ErrNum,
+			},
+			{
+				args:     testArgs{math.NaN(), 36, 800_000, 6, 12, false},
+				expected: ErrValue,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			_, err := CumipmtFloat64E(
+				args.rate,
+				args.nper,
+				args.pv,
+				args.start,
+				args.end,
+				args.paymentFlag,
+			)
+			assert.ErrorIs(t, err, testCase.expected, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		actual, err := CumipmtFloat64E(0.1, 36, 800_000, 6, 12, true)
+		assert.NoError(t, err)
+		assert.InDelta(t, -488_961.571129, actual, DELTA)
+
+		actual, err = CumipmtFloat64E(-0.005, 36, 800_000, 6, 12, true)
+		assert.NoError(t, err)
+		assert.InDelta(t, 21_449.479112, actual, DELTA)
+	})
+}
+
+func TestCumipmtE(t *testing.T) {
+	actual, err := CumipmtE(0.1, 36, 800_000, 6, 12, true)
+	assert.NoError(t, err)
+	assert.Equal(t, -488_962, actual)
+}
+
+func TestDollarDeE(t *testing.T) {
+	_, err := DollarDeE(1.02, -16)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = DollarDeE(1.02, 0.5)
+	assert.ErrorIs(t, err, ErrDiv0)
+
+	actual, err := DollarDeE(1.02, 16)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.125, actual, DELTA)
+}
+
+func TestDollarFrE(t *testing.T) {
+	_, err := DollarFrE(1.125, -16)
+	assert.ErrorIs(t, err, ErrNum)
+
+	_, err = DollarFrE(1.125, 0)
+	assert.ErrorIs(t, err, ErrDiv0)
+
+	actual, err := DollarFrE(1.125, 16)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.02, actual, DELTA)
+}
@@ -0,0 +1,31 @@
+package xlsxfin
+
+import "math"
+
+// PmtFrac and the other Frac functions take the number of periods as
+// float64, so that for example an NPER result can be passed back in. The
+// fractional value is used as is, except by CumipmtFrac.
+func PmtFrac(rate float64, nper float64, pv float64, fv float64, paymentFlag bool) float64 {
+	return pmt(rate, nper, pv, fv, paymentFlag)
+}
+
+func IpmtFrac(rate float64, per float64, nper float64, pv float64, fv float64, paymentFlag bool) float64 {
+	return ipmt(rate, per, nper, pv, fv, paymentFlag)
+}
+
+func PpmtFrac(rate float64, per float64, nper float64, pv float64, fv float64, paymentFlag bool) float64 {
+	if per < 1 || per >= nper+1 {
+		return 0
+	}
+	return pmt(rate, nper, pv, fv, paymentFlag) - ipmt(rate, per, nper, pv, fv, paymentFlag)
+}
+
+func FvFrac(rate float64, nper float64, pmt float64, pv float64, paymentFlag bool) float64 {
+	return fv(rate, nper, pmt, pv, paymentFlag)
+}
+
+// CumipmtFrac truncates nper, start and end to integers, as Excel does.
+func CumipmtFrac(rate float64, nper float64, pv float64, start float64, end float64, paymentFlag bool) float64 {
+	loan := Loan{rate, int(math.Trunc(nper)), pv, 0, timingOf(paymentFlag)}
+	return loan.Cumipmt(int(math.Trunc(start)), int(math.Trunc(end)))
+}
@@ -0,0 +1,89 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePmtFrac() {
+	v := PmtFrac(0.01, 30.5, 100_000, 0, false)
+	fmt.Printf("%.2f\n", v)
+	// Output: -3820.31
+}
+
+func TestPmtFrac(t *testing.T) {
+	t.Run("nper is 0", func(t *testing.T) {
+		assert.Equal(t, 0.0, PmtFrac(0.01, 0, 100_000, 0, false))
+	})
+
+	t.Run("integral nper matches PmtF64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			expected := PmtF64(0.3, 36, 100_000, 1_000, paymentFlag)
+			actual := PmtFrac(0.3, 36, 100_000, 1_000, paymentFlag)
+			assert.Equal(t, expected, actual, paymentFlag)
+		}
+	})
+
+	t.Run("fractional nper", func(t *testing.T) {
+		assert.InDelta(t, -3_820.306468, PmtFrac(0.01, 30.5, 100_000, 0, false), DELTA)
+		assert.InDelta(t, -3_782.481651, PmtFrac(0.01, 30.5, 100_000, 0, true), DELTA)
+		assert.InDelta(t, -3_278.688525, PmtFrac(0, 30.5, 100_000, 0, false), DELTA)
+	})
+
+	t.Run("repays the loan", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			pmt := PmtFrac(0.01, 30.5, 100_000, 0, paymentFlag)
+			assert.InDelta(t, 0, FvFrac(0.01, 30.5, pmt, 100_000, paymentFlag), DELTA, paymentFlag)
+		}
+	})
+}
+
+func TestIpmtFrac(t *testing.T) {
+	t.Run("integral per matches IpmtF64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			expected := IpmtF64(0.1, 2, 36, 800_000, 1_000, paymentFlag)
+			actual := IpmtFrac(0.1, 2, 36, 800_000, 1_000, paymentFlag)
+			assert.Equal(t, expected, actual, paymentFlag)
+		}
+	})
+
+	t.Run("fractional per lies between its neighbours", func(t *testing.T) {
+		before := IpmtFrac(0.01, 2, 30.5, 100_000, 0, false)
+		actual := IpmtFrac(0.01, 2.5, 30.5, 100_000, 0, false)
+		after := IpmtFrac(0.01, 3, 30.5, 100_000, 0, false)
+		assert.Less(t, before, actual)
+		assert.Less(t, actual, after)
+	})
+}
+
+func TestPpmtFrac(t *testing.T) {
+	t.Run("per is out of range", func(t *testing.T) {
+		assert.Equal(t, 0.0, PpmtFrac(0.01, 0.5, 30.5, 100_000, 0, false))
+		assert.Equal(t, 0.0, PpmtFrac(0.01, 31.5, 30.5, 100_000, 0, false))
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			pmt := PmtFrac(0.01, 30.5, 100_000, 0, paymentFlag)
+			ipmt := IpmtFrac(0.01, 2.5, 30.5, 100_000, 0, paymentFlag)
+			actual := PpmtFrac(0.01, 2.5, 30.5, 100_000, 0, paymentFlag)
+			assert.InDelta(t, pmt-ipmt, actual, DELTA, paymentFlag)
+		}
+	})
+}
+
+func TestFvFrac(t *testing.T) {
+	assert.InDelta(t, 35_457.139552, FvFrac(0.01, 30.5, -1_000, 0, false), DELTA)
+	assert.InDelta(t, -135_457.139552, FvFrac(0.01, 30.5, 0, 100_000, false), DELTA)
+	assert.Equal(t, FvF64(0.1, 12, 10_000, 0, true), FvFrac(0.1, 12, 10_000, 0, true))
+}
+
+func TestCumipmtFrac(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		expected := CumipmtF64(0.1, 36, 800_000, 6, 12, paymentFlag)
+		actual := CumipmtFrac(0.1, 36.9, 800_000, 6.2, 12.7, paymentFlag)
+		assert.Equal(t, expected, actual, paymentFlag)
+	}
+}
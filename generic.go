@@ -0,0 +1,174 @@
+package xlsxfin
+
+// Number is the set of built-in types the Of functions accept for amounts.
+// Integer results are rounded with the mode passed to them; float results
+// are not rounded.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+func fromFloat64[T Number](f float64, mode RoundingMode) T {
+	half := 0.5
+	if T(half) == 0 {
+		return T(mode.Round(f))
+	}
+	return T(f)
+}
+
+func PmtOf[T Number](mode RoundingMode, rate float64, nper int, pv T, fv T, timing PaymentTiming) T {
+	return fromFloat64[T](Loan{rate, nper, float64(pv), float64(fv), timing}.Pmt(), mode)
+}
+
+func IpmtOf[T Number](mode RoundingMode, rate float64, per int, nper int, pv T, fv T, timing PaymentTiming) T {
+	return fromFloat64[T](Loan{rate, nper, float64(pv), float64(fv), timing}.Ipmt(per), mode)
+}
+
+func PpmtOf[T Number](mode RoundingMode, rate float64, per int, nper int, pv T, fv T, timing PaymentTiming) T {
+	return fromFloat64[T](Loan{rate, nper, float64(pv), float64(fv), timing}.Ppmt(per), mode)
+}
+
+func FvOf[T Number](mode RoundingMode, rate float64, nper int, pmt T, pv T, timing PaymentTiming) T {
+	return fromFloat64[T](Loan{rate, nper, float64(pv), 0, timing}.FutureValue(float64(pmt)), mode)
+}
+
+func CumipmtOf[T Number](mode RoundingMode, rate float64, nper int, pv T, start int, end int, timing PaymentTiming) T {
+	return fromFloat64[T](Loan{rate, nper, float64(pv), 0, timing}.Cumipmt(start, end), mode)
+}
+
+// Decimal is implemented by user-defined decimal types so that the Decimal
+// functions can compute in them directly. Methods must return new values
+// rather than modify their receiver, and FromInt must also work on the zero
+// value. Div is never called with a zero divisor.
+type Decimal[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(T) T
+	Div(T) T
+	Sign() int
+	FromInt(int64) T
+}
+
+func decimalPow[T Decimal[T]](x T, n int) T {
+	result := x.FromInt(1)
+	base := x
+	negative := n < 0
+	if negative {
+		n = -n
+	}
+	for n > 0 {
+		if n&1 == 1 {
+			result = result.Mul(base)
+		}
+		base = base.Mul(base)
+		n >>= 1
+	}
+	if negative && result.Sign() != 0 {
+		result = x.FromInt(1).Div(result)
+	}
+	return result
+}
+
+func PmtDecimal[T Decimal[T]](rate T, nper int, pv T, fv T, timing PaymentTiming) T {
+	zero := rate.FromInt(0)
+	if nper == 0 {
+		return zero
+	}
+	if rate.Sign() == 0 {
+		return zero.Sub(pv.Add(fv).Div(rate.FromInt(int64(nper))))
+	}
+
+	onePlusRate := rate.FromInt(1).Add(rate)
+	pvif := decimalPow(onePlusRate, nper)
+	denominator := pvif.Sub(rate.FromInt(1))
+	if denominator.Sign() == 0 {
+		return zero
+	}
+
+	pmt := zero.Sub(pv.Mul(pvif).Add(fv).Mul(rate).Div(denominator))
+	if timing != BeginningOfPeriod {
+		return pmt
+	}
+	return pmt.Div(onePlusRate)
+}
+
+func IpmtDecimal[T Decimal[T]](rate T, per int, nper int, pv T, fv T, timing PaymentTiming) T {
+	zero := rate.FromInt(0)
+	onePlusRate := rate.FromInt(1).Add(rate)
+	if nper == 0 || per == 0 || onePlusRate.Sign() <= 0 || timing == BeginningOfPeriod && per == 1 {
+		return zero
+	}
+
+	pmt := PmtDecimal(rate, nper, pv, fv, EndOfPeriod)
+	n := decimalPow(onePlusRate, per-1)
+	m := n.Sub(rate.FromInt(1))
+
+	ip := zero.Sub(pv.Mul(n).Mul(rate).Add(pmt.Mul(m)))
+	if timing != BeginningOfPeriod {
+		return ip
+	}
+	return ip.Div(onePlusRate)
+}
+
+func PpmtDecimal[T Decimal[T]](rate T, per int, nper int, pv T, fv T, timing PaymentTiming) T {
+	if per < 1 || per >= nper+1 {
+		return rate.FromInt(0)
+	}
+	pmt := PmtDecimal(rate, nper, pv, fv, timing)
+	return pmt.Sub(IpmtDecimal(rate, per, nper, pv, fv, timing))
+}
+
+func FvDecimal[T Decimal[T]](rate T, nper int, pmt T, pv T, timing PaymentTiming) T {
+	zero := rate.FromInt(0)
+	if rate.Sign() == 0 {
+		return zero.Sub(pv.Add(pmt.Mul(rate.FromInt(int64(nper)))))
+	}
+
+	onePlusRate := rate.FromInt(1).Add(rate)
+	term := decimalPow(onePlusRate, nper)
+	annuity := term.Sub(rate.FromInt(1)).Mul(pmt)
+	if timing == BeginningOfPeriod {
+		annuity = annuity.Mul(onePlusRate)
+	}
+	return zero.Sub(pv.Mul(term).Add(annuity.Div(rate)))
+}
+
+// CumipmtDecimal sums the interest of periods start to end in closed form,
+// like Loan.Cumipmt. The interest of period i is rate times the balance
+// -(pv*q^k + pmt*c*(q^k-1)/rate) with q = 1+rate, k = i-1 and c = 1, or
+// under BeginningOfPeriod less pmt with k = i-2 and c = q, and none in the
+// first period. Summed over the range this is a geometric series in q, so
+// the number of operations does not depend on the range. With an exact T
+// such as big.Rat, the digits of q^k still grow with k and so does the
+// time each operation takes.
+func CumipmtDecimal[T Decimal[T]](rate T, nper int, pv T, start int, end int, timing PaymentTiming) T {
+	zero := rate.FromInt(0)
+	if rate.FromInt(1).Add(rate).Sign() <= 0 || nper <= 0 || pv.Sign() <= 0 {
+		return zero
+	}
+
+	if start < 1 || end < 1 || start > end {
+		return zero
+	}
+
+	if rate.Sign() == 0 {
+		return zero
+	}
+
+	onePlusRate := rate.FromInt(1).Add(rate)
+	pmt := PmtDecimal(rate, nper, pv, zero, timing)
+	first, c := start-1, rate.FromInt(1)
+	if timing == BeginningOfPeriod {
+		if start == 1 {
+			start++
+		}
+		if start > end {
+			return zero
+		}
+		first, c = start-2, onePlusRate
+	}
+	count := end - start + 1
+
+	// sum of q^k for k = first .. first+count-1
+	powers := decimalPow(onePlusRate, first).Mul(decimalPow(onePlusRate, count).Sub(rate.FromInt(1))).Div(rate)
+	return pmt.Mul(rate.FromInt(int64(count))).Sub(pv.Mul(rate).Add(pmt.Mul(c)).Mul(powers))
+}
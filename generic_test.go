@@ -0,0 +1,140 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFloat struct {
+	f float64
+}
+
+func (x testFloat) Add(y testFloat) testFloat { return testFloat{x.f + y.f} }
+func (x testFloat) Sub(y testFloat) testFloat { return testFloat{x.f - y.f} }
+func (x testFloat) Mul(y testFloat) testFloat { return testFloat{x.f * y.f} }
+func (x testFloat) Div(y testFloat) testFloat { return testFloat{x.f / y.f} }
+func (x testFloat) Sign() int {
+	switch {
+	case x.f < 0:
+		return -1
+	case x.f > 0:
+		return 1
+	}
+	return 0
+}
+func (testFloat) FromInt(n int64) testFloat { return testFloat{float64(n)} }
+
+func ExamplePmtOf() {
+	var pv int64 = 1_000_000
+	v := PmtOf(RoundHalfAwayFromZero, 0.08/12, 10, pv, 0, EndOfPeriod)
+	fmt.Println(v)
+	// Output: -103703
+}
+
+func TestFromFloat64(t *testing.T) {
+	assert.Equal(t, -3, fromFloat64[int](-2.5, RoundHalfAwayFromZero))
+	assert.Equal(t, int8(3), fromFloat64[int8](2.5, RoundHalfAwayFromZero))
+	assert.Equal(t, Money(-103_703), fromFloat64[Money](-103_703.2, RoundHalfAwayFromZero))
+	assert.Equal(t, -2.5, fromFloat64[float64](-2.5, RoundHalfAwayFromZero))
+	assert.Equal(t, float32(-2.5), fromFloat64[float32](-2.5, RoundHalfAwayFromZero))
+}
+
+func TestPmtOf(t *testing.T) {
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		due := timing == BeginningOfPeriod
+		assert.Equal(t, Pmt(0.3, 36, 100_000, 1_000, due), PmtOf(RoundHalfAwayFromZero, 0.3, 36, 100_000, 1_000, timing))
+		assert.Equal(t, int64(Pmt(0.3, 36, 100_000, 1_000, due)), PmtOf[int64](RoundHalfAwayFromZero, 0.3, 36, 100_000, 1_000, timing))
+		assert.Equal(t, PmtF64(0.01, 12, 1_234.56, 0.5, due), PmtOf(RoundHalfAwayFromZero, 0.01, 12, 1_234.56, 0.5, timing))
+		assert.Equal(t, Money(Pmt(0.3, 36, 100_000, 1_000, due)), PmtOf[Money](RoundHalfAwayFromZero, 0.3, 36, 100_000, 1_000, timing))
+	}
+}
+
+func TestIpmtOf(t *testing.T) {
+	assert.Equal(t, Ipmt(0.1, 2, 36, 800_000, 0, true), IpmtOf(RoundHalfAwayFromZero, 0.1, 2, 36, 800_000, 0, BeginningOfPeriod))
+	assert.Equal(t, IpmtF64(0.1, 2, 36, 800_000, 0, false), IpmtOf(RoundHalfAwayFromZero, 0.1, 2, 36, 800_000.0, 0, EndOfPeriod))
+}
+
+func TestPpmtOf(t *testing.T) {
+	assert.Equal(t, Ppmt(0.1, 12, 36, 800_000, 0, false), PpmtOf(RoundHalfAwayFromZero, 0.1, 12, 36, 800_000, 0, EndOfPeriod))
+	assert.Equal(t, float32(PpmtF64(0.1, 12, 36, 800_000, 0, true)), PpmtOf[float32](RoundHalfAwayFromZero, 0.1, 12, 36, 800_000, 0, BeginningOfPeriod))
+}
+
+func TestFvOf(t *testing.T) {
+	assert.Equal(t, Fv(0.1, 12, 10_000, 0, false), FvOf(RoundHalfAwayFromZero, 0.1, 12, 10_000, 0, EndOfPeriod))
+	assert.Equal(t, FvF64(0.1, 12, 10_000, 0, true), FvOf(RoundHalfAwayFromZero, 0.1, 12, 10_000.0, 0, BeginningOfPeriod))
+}
+
+func TestCumipmtOf(t *testing.T) {
+	assert.Equal(t, Cumipmt(0.1, 36, 800_000, 6, 12, true), CumipmtOf(RoundHalfAwayFromZero, 0.1, 36, 800_000, 6, 12, BeginningOfPeriod))
+	assert.Equal(t, int32(Cumipmt(0.1, 36, 800_000, 6, 12, false)), CumipmtOf[int32](RoundHalfAwayFromZero, 0.1, 36, 800_000, 6, 12, EndOfPeriod))
+}
+
+func TestDecimalPow(t *testing.T) {
+	assert.Equal(t, 1024.0, decimalPow(testFloat{2}, 10).f)
+	assert.Equal(t, 1.0, decimalPow(testFloat{2}, 0).f)
+	assert.Equal(t, 0.125, decimalPow(testFloat{2}, -3).f)
+	assert.Equal(t, "1/1000", decimalPow(ratDecimal{big.NewRat(1, 10)}, 3).r.RatString())
+}
+
+func TestPmtDecimal(t *testing.T) {
+	t.Run("nper is 0", func(t *testing.T) {
+		assert.Equal(t, 0.0, PmtDecimal(testFloat{0.3}, 0, testFloat{100_000}, testFloat{0}, EndOfPeriod).f)
+	})
+
+	t.Run("denominator is 0", func(t *testing.T) {
+		assert.Equal(t, 0.0, PmtDecimal(testFloat{-2}, 2, testFloat{100_000}, testFloat{0}, EndOfPeriod).f)
+	})
+
+	t.Run("matches PmtF64", func(t *testing.T) {
+		for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+			for _, rate := range []float64{0, 0.3} {
+				expected := Loan{rate, 36, 100_000, 1_000, timing}.Pmt()
+				actual := PmtDecimal(testFloat{rate}, 36, testFloat{100_000}, testFloat{1_000}, timing)
+				assert.InDelta(t, expected, actual.f, DELTA, rate, timing)
+			}
+		}
+	})
+}
+
+func TestIpmtDecimal(t *testing.T) {
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, per := range []int{0, 1, 2, 36} {
+			expected := Loan{0.1, 36, 800_000, 1_000, timing}.Ipmt(per)
+			actual := IpmtDecimal(testFloat{0.1}, per, 36, testFloat{800_000}, testFloat{1_000}, timing)
+			assert.InDelta(t, expected, actual.f, DELTA, per, timing)
+		}
+	}
+}
+
+func TestPpmtDecimal(t *testing.T) {
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, per := range []int{0, 1, 12, 36, 37} {
+			expected := Loan{0.1, 36, 800_000, 0, timing}.Ppmt(per)
+			actual := PpmtDecimal(testFloat{0.1}, per, 36, testFloat{800_000}, testFloat{0}, timing)
+			assert.InDelta(t, expected, actual.f, DELTA, per, timing)
+		}
+	}
+}
+
+func TestFvDecimal(t *testing.T) {
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, rate := range []float64{0, 0.1} {
+			expected := Loan{rate, 12, 1_000, 0, timing}.FutureValue(10_000)
+			actual := FvDecimal(testFloat{rate}, 12, testFloat{10_000}, testFloat{1_000}, timing)
+			assert.InDelta(t, expected, actual.f, DELTA, rate, timing)
+		}
+	}
+}
+
+func TestCumipmtDecimal(t *testing.T) {
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, start := range []int{0, 1, 6, 13} {
+			expected := Loan{0.1, 36, 800_000, 0, timing}.Cumipmt(start, 12)
+			actual := CumipmtDecimal(testFloat{0.1}, 36, testFloat{800_000}, start, 12, timing)
+			assert.InDelta(t, expected, actual.f, DELTA, start, timing)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package xlsxfin
+
+import "math"
+
+// Grace is what a GraceLoan pays during its grace period (据置期間).
+type Grace int
+
+const (
+	// InterestOnly pays the interest of every grace period and no principal.
+	InterestOnly Grace = iota
+	// Deferral pays nothing; the interest is added to the balance, which is
+	// then amortized.
+	Deferral
+)
+
+// GraceLoan is a loan whose first GracePeriods periods repay no principal
+// before it is amortized over the remaining Nper-GracePeriods periods. Base
+// holds the rate, the whole term in Nper, Pv and Fv; only EndOfPeriod is
+// supported.
+type GraceLoan struct {
+	Base         Loan
+	GracePeriods int
+	Grace        Grace
+}
+
+func (l GraceLoan) Validate() error {
+	if err := l.Base.Validate(); err != nil {
+		return err
+	}
+	if l.Base.Timing != EndOfPeriod || l.GracePeriods < 0 || l.GracePeriods >= l.Base.Nper {
+		return ErrNum
+	}
+	if l.Grace != InterestOnly && l.Grace != Deferral {
+		return ErrNum
+	}
+	return nil
+}
+
+// graceBalance returns the balance after k grace periods.
+func (l GraceLoan) graceBalance(k int) float64 {
+	if l.Grace == InterestOnly {
+		return l.Base.Pv
+	}
+	return l.Base.Pv * math.Pow(1+l.Base.Rate, float64(k))
+}
+
+// Amortization returns the loan that starts when the grace period ends: the
+// balance left then, repaid over the remaining periods.
+func (l GraceLoan) Amortization() Loan {
+	return Loan{Rate: l.Base.Rate, Nper: l.Base.Nper - l.GracePeriods, Pv: l.graceBalance(l.GracePeriods), Fv: l.Base.Fv}
+}
+
+// Pmt returns the level payment once the grace period ends.
+func (l GraceLoan) Pmt() float64 {
+	return l.Amortization().Pmt()
+}
+
+// Ipmt returns the interest of period per with level amortization after
+// the grace period, or 0 if per is outside 1 to Nper.
+func (l GraceLoan) Ipmt(per int) float64 {
+	if per < 1 || per > l.Base.Nper {
+		return 0
+	}
+	if per <= l.GracePeriods {
+		return -l.Base.Rate * l.graceBalance(per-1)
+	}
+	return l.Amortization().Ipmt(per - l.GracePeriods)
+}
+
+// Ppmt returns the principal of period per with level amortization after
+// the grace period, or 0 if per is outside 1 to Nper. Under Deferral the
+// principal of a grace period is the interest added to the balance, so it
+// is positive.
+func (l GraceLoan) Ppmt(per int) float64 {
+	if per < 1 || per > l.Base.Nper {
+		return 0
+	}
+	if per <= l.GracePeriods {
+		if l.Grace == InterestOnly {
+			return 0
+		}
+		return -l.Ipmt(per)
+	}
+	return l.Amortization().Ppmt(per - l.GracePeriods)
+}
+
+// Schedule returns the schedule of the grace period followed by level
+// payments, or nil if the loan fails Validate. A grace period's Payment is
+// its Interest under InterestOnly and 0 under Deferral, where the interest
+// becomes a positive Principal that raises the balance.
+func (l GraceLoan) Schedule() Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+	return l.merge(l.Amortization().Schedule())
+}
+
+// EqualPrincipalSchedule is Schedule with the balance left after the grace
+// period repaid in equal principal installments, as by
+// Loan.EqualPrincipalSchedule.
+func (l GraceLoan) EqualPrincipalSchedule() Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+	return l.merge(l.Amortization().EqualPrincipalSchedule())
+}
+
+// merge puts the grace periods before the amortization's schedule.
+func (l GraceLoan) merge(amortization Schedule) Schedule {
+	schedule := make(Schedule, 0, l.Base.Nper)
+	cumulativeInterest := 0.0
+	for per := 1; per <= l.GracePeriods; per++ {
+		installment := Installment{
+			Period:         per,
+			Interest:       l.Ipmt(per),
+			Principal:      l.Ppmt(per),
+			OpeningBalance: l.graceBalance(per - 1),
+			ClosingBalance: l.graceBalance(per),
+		}
+		if l.Grace == InterestOnly {
+			installment.Payment = installment.Interest
+		}
+		cumulativeInterest += installment.Interest
+		installment.CumulativeInterest = cumulativeInterest
+		schedule = append(schedule, installment)
+	}
+	for _, installment := range amortization {
+		installment.Period += l.GracePeriods
+		installment.CumulativeInterest += cumulativeInterest
+		schedule = append(schedule, installment)
+	}
+	return schedule
+}
@@ -0,0 +1,112 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleGraceLoan_Schedule() {
+	loan := GraceLoan{Base: Loan{Rate: 0.02 / 12, Nper: 60, Pv: 10_000_000}, GracePeriods: 12}
+	schedule := loan.Schedule()
+	for _, per := range []int{1, 12, 13, 60} {
+		installment := schedule[per-1]
+		fmt.Printf("%d %.0f %.0f %.0f %.0f\n", per, installment.Payment, installment.Interest, installment.Principal, installment.ClosingBalance)
+	}
+	// Output:
+	// 1 -16667 -16667 0 10000000
+	// 12 -16667 -16667 0 10000000
+	// 13 -216951 -16667 -200285 9799715
+	// 60 -216951 -361 -216590 0
+}
+
+func TestGraceLoanValidate(t *testing.T) {
+	loan := Loan{Rate: 0.02 / 12, Nper: 60, Pv: 10_000_000}
+	assert.NoError(t, GraceLoan{Base: loan, GracePeriods: 59}.Validate())
+
+	for _, invalid := range []GraceLoan{
+		{Base: Loan{Rate: 0.01, Nper: 0, Pv: 1_000}},
+		{Base: Loan{Rate: 0.01, Nper: 12, Pv: 1_000, Timing: BeginningOfPeriod}},
+		{Base: loan, GracePeriods: -1},
+		{Base: loan, GracePeriods: 60},
+		{Base: loan, Grace: 2},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), ErrNum, fmt.Sprint(invalid))
+		assert.Nil(t, invalid.Schedule())
+		assert.Nil(t, invalid.EqualPrincipalSchedule())
+	}
+}
+
+func TestGraceLoanSchedule(t *testing.T) {
+	t.Run("without grace", func(t *testing.T) {
+		loan := Loan{Rate: 0.035 / 12, Nper: 36, Pv: 800_000, Fv: 100_000}
+		grace := GraceLoan{Base: loan}
+		assert.Equal(t, loan.Schedule(), grace.Schedule())
+		assert.Equal(t, loan.EqualPrincipalSchedule(), grace.EqualPrincipalSchedule())
+		for per := 0; per <= 36; per++ {
+			assert.Equal(t, loan.Ipmt(per), grace.Ipmt(per), per)
+			assert.Equal(t, loan.Ppmt(per), grace.Ppmt(per), per)
+		}
+	})
+
+	for _, kind := range []Grace{InterestOnly, Deferral} {
+		for _, loan := range []Loan{
+			{Rate: 0.02 / 12, Nper: 60, Pv: 10_000_000},
+			{Rate: 0.1, Nper: 20, Pv: 800_000, Fv: 100_000},
+			{Rate: 0, Nper: 24, Pv: 1_000_000},
+			{Rate: -0.001, Nper: 36, Pv: 1_000_000},
+		} {
+			grace := GraceLoan{Base: loan, GracePeriods: 6, Grace: kind}
+			args := fmt.Sprint(grace)
+			amortization := grace.Amortization()
+			assert.Equal(t, loan.Nper-6, amortization.Nper, args)
+
+			for _, schedule := range []Schedule{grace.Schedule(), grace.EqualPrincipalSchedule()} {
+				assert.Len(t, schedule, loan.Nper, args)
+				cumulativeInterest := 0.0
+				for i, installment := range schedule {
+					per := i + 1
+					assert.Equal(t, per, installment.Period, args)
+					assert.InDelta(t, installment.Interest+installment.Principal, installment.Payment, DELTA, "%v %d", args, per)
+					assert.InDelta(t, installment.OpeningBalance+installment.Principal, installment.ClosingBalance, DELTA, "%v %d", args, per)
+					assert.InDelta(t, -loan.Rate*installment.OpeningBalance, installment.Interest, DELTA, "%v %d", args, per)
+					if i > 0 {
+						assert.InDelta(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, DELTA, args)
+					}
+					cumulativeInterest += installment.Interest
+					assert.InDelta(t, cumulativeInterest, installment.CumulativeInterest, DELTA, "%v %d", args, per)
+
+					if per <= 6 {
+						assert.Equal(t, grace.Ipmt(per), installment.Interest, "%v %d", args, per)
+						assert.Equal(t, grace.Ppmt(per), installment.Principal, "%v %d", args, per)
+						if kind == InterestOnly {
+							assert.Equal(t, installment.Interest, installment.Payment, args)
+							assert.Equal(t, 0.0, installment.Principal, args)
+						} else {
+							assert.Equal(t, 0.0, installment.Payment, args)
+						}
+					}
+				}
+				assert.Equal(t, loan.Pv, schedule[0].OpeningBalance, args)
+				assert.InDelta(t, amortization.Pv, schedule[5].ClosingBalance, DELTA, args)
+				assert.InDelta(t, -loan.Fv, schedule[loan.Nper-1].ClosingBalance, DELTA, args)
+			}
+
+			schedule := grace.Schedule()
+			for per := 7; per <= loan.Nper; per++ {
+				assert.Equal(t, grace.Pmt(), schedule[per-1].Payment, "%v %d", args, per)
+				assert.Equal(t, grace.Ipmt(per), schedule[per-1].Interest, "%v %d", args, per)
+				assert.InDelta(t, grace.Ppmt(per), schedule[per-1].Principal, DELTA, "%v %d", args, per)
+			}
+			assert.Equal(t, 0.0, grace.Ipmt(0), args)
+			assert.Equal(t, 0.0, grace.Ppmt(loan.Nper+1), args)
+		}
+	}
+
+	t.Run("deferral capitalizes interest", func(t *testing.T) {
+		grace := GraceLoan{Base: Loan{Rate: 0.01, Nper: 24, Pv: 1_000_000}, GracePeriods: 12, Grace: Deferral}
+		assert.InDelta(t, 1_000_000*1.126825030131969720661201, grace.Amortization().Pv, DELTA)
+		assert.InDelta(t, PmtF64(0.01, 12, grace.Amortization().Pv, 0, false), grace.Pmt(), DELTA)
+	})
+}
@@ -0,0 +1,268 @@
+package xlsxfin
+
+import "math"
+
+// PaymentTiming tells whether payments are due at the end or at the
+// beginning of each period. It replaces the paymentFlag argument: false is
+// EndOfPeriod and true is BeginningOfPeriod.
+type PaymentTiming int
+
+const (
+	EndOfPeriod PaymentTiming = iota
+	BeginningOfPeriod
+)
+
+func timingOf(paymentFlag bool) PaymentTiming {
+	if paymentFlag {
+		return BeginningOfPeriod
+	}
+	return EndOfPeriod
+}
+
+// Loan holds the arguments shared by the annuity functions, so that call
+// sites name them instead of passing a row of bare numbers:
+//
+//	loan := Loan{Rate: 0.08 / 12, Nper: 10, Pv: 1_000_000}
+//	pmt := loan.Pmt()
+type Loan struct {
+	Rate   float64
+	Nper   int
+	Pv     float64
+	Fv     float64
+	Timing PaymentTiming
+}
+
+func (l Loan) due() bool {
+	return l.Timing == BeginningOfPeriod
+}
+
+func (l Loan) Validate() error {
+	if err := checkArgs(l.Rate, l.Pv, l.Fv); err != nil {
+		return err
+	}
+	if l.Rate <= -1 || l.Nper <= 0 {
+		return ErrNum
+	}
+	if l.Timing != EndOfPeriod && l.Timing != BeginningOfPeriod {
+		return ErrNum
+	}
+	return nil
+}
+
+// annuity holds the factors of a rate compounded over nper periods that
+// Pmt, Ipmt and Fv share, so that batches of loans on the same terms
+// compute them once.
+//
+// pvif and pvifSub1, (1+rate)^nper and (1+rate)^nper - 1, both come from
+// x = nper*log1p(rate) through exp and expm1, so the second value does not
+// cancel when rate*nper is small: the relative error of either is at most
+// about 2|x|+3 ULPs. Computing math.Pow(1+rate, nper) - 1 instead rounds
+// 1+rate first and loses roughly -log10(|rate|) significant digits, which
+// for a daily rate of 0.00001 leaves only about 11.
+//
+// A rate of -1 or less has no logarithm; math.Pow is used then so that
+// integral nper keeps working.
+type annuity struct {
+	rate     float64
+	nper     float64
+	log1p    float64
+	pvif     float64
+	pvifSub1 float64
+}
+
+func newAnnuity(rate float64, nper float64) annuity {
+	a := annuity{rate: rate, nper: nper}
+	if rate <= -1 {
+		a.log1p = math.NaN()
+		a.pvif = math.Pow(1.0+rate, nper)
+		a.pvifSub1 = a.pvif - 1
+		return a
+	}
+	a.log1p = math.Log1p(rate)
+	x := nper * a.log1p
+	a.pvif = math.Exp(x)
+	a.pvifSub1 = math.Expm1(x)
+	return a
+}
+
+func (a annuity) pmt(pv float64, fv float64, due bool) float64 {
+	if a.nper == 0 {
+		return 0
+	}
+	if a.rate == 0.0 {
+		return -(pv + fv) / a.nper
+	}
+
+	pmt := (a.rate / a.pvifSub1) * -(pv*a.pvif + fv)
+
+	if !due {
+		return pmt
+	}
+	return pmt / (1 + a.rate)
+}
+
+func (a annuity) ipmt(per float64, pv float64, fv float64, due bool) float64 {
+	if a.nper == 0 {
+		return 0.0
+	}
+
+	// Like Excel, the first payment under BeginningOfPeriod is due before
+	// any interest accrues.
+	if per == 0 || due && per == 1 {
+		return 0.0
+	}
+
+	if a.rate <= -1 {
+		return 0.0
+	}
+
+	if a.rate == 0.0 {
+		return 0.0
+	}
+
+	ip := -a.balance(per-1, pv, fv) * a.rate
+	if !due {
+		return ip
+	}
+	return ip / (1.0 + a.rate)
+}
+
+// balance returns the balance outstanding after k payments at the end of
+// each period, written without the subtraction of pv*(1+rate)^k and the
+// annuity of the payments, which cancel almost entirely for long terms. It
+// is pv for k = 0 and exactly -fv for k = nper. The rate must be above -1.
+func (a annuity) balance(k float64, pv float64, fv float64) float64 {
+	if a.rate == 0.0 {
+		return pv - (pv+fv)*(k/a.nper)
+	}
+	l := a.log1p
+	grownSub1 := math.Expm1(k * l)
+	return (pv*(1+grownSub1)*math.Expm1((a.nper-k)*l) - fv*grownSub1) / a.pvifSub1
+}
+
+func (a annuity) fv(pmt float64, pv float64, due bool) float64 {
+	if a.rate == 0 {
+		return -(pv + pmt*a.nper)
+	}
+	if due {
+		return -(pv*a.pvif + (pmt*(1+a.rate)*a.pvifSub1)/a.rate)
+	}
+	return -(pv*a.pvif + (pmt*a.pvifSub1)/a.rate)
+}
+
+func pmt(rate float64, nper float64, pv float64, fv float64, due bool) float64 {
+	return newAnnuity(rate, nper).pmt(pv, fv, due)
+}
+
+func ipmt(rate float64, per float64, nper float64, pv float64, fv float64, due bool) float64 {
+	return newAnnuity(rate, nper).ipmt(per, pv, fv, due)
+}
+
+func fv(rate float64, nper float64, pmt float64, pv float64, due bool) float64 {
+	return newAnnuity(rate, nper).fv(pmt, pv, due)
+}
+
+func (l Loan) Pmt() float64 {
+	return pmt(l.Rate, float64(l.Nper), l.Pv, l.Fv, l.due())
+}
+
+func (l Loan) Ipmt(per int) float64 {
+	return ipmt(l.Rate, float64(per), float64(l.Nper), l.Pv, l.Fv, l.due())
+}
+
+func (l Loan) Ppmt(per int) float64 {
+	if per < 1 || per >= l.Nper+1 {
+		return 0
+	}
+	return l.Pmt() - l.Ipmt(per)
+}
+
+// FutureValue is Excel's FV for the loan's Rate, Nper, Pv and Timing when pmt
+// is paid every period. Fv is not used.
+func (l Loan) FutureValue(pmt float64) float64 {
+	return fv(l.Rate, float64(l.Nper), pmt, l.Pv, l.due())
+}
+
+// Cumipmt is Excel's CUMIPMT. Like Excel it assumes the loan is repaid in
+// full, so Fv is not used.
+//
+// It runs in constant time. The interest of a payment is -Rate times the
+// balance it pays down, and the balances sum in closed form (see
+// sumBalances), so no period is visited. Under BeginningOfPeriod the first
+// payment carries no interest and the others pay interest on a balance
+// discounted by one period.
+func (l Loan) Cumipmt(start int, end int) float64 {
+	if l.Rate <= -1 || l.Nper <= 0 || l.Pv <= 0 {
+		return 0.0
+	}
+
+	if start < 1 || end < 1 || start > end {
+		return 0.0
+	}
+
+	if l.Rate == 0 {
+		return 0.0
+	}
+
+	if !l.due() {
+		return -l.Rate * sumBalances(l.Rate, l.Nper, l.Pv, start-1, end-1)
+	}
+	if start == 1 {
+		start++
+	}
+	if start > end {
+		return 0.0
+	}
+	return -l.Rate / (1 + l.Rate) * sumBalances(l.Rate, l.Nper, l.Pv, start-1, end-1)
+}
+
+// sumBalances returns the sum of the balances left after payments first to
+// last of a loan of pv repaid by nper level payments at the end of each
+// period.
+//
+// With L = log1p(rate) the balance after j payments is
+// pv*expm1(-(nper-j)*L)/expm1(-nper*L), so the sum is a geometric series in
+// exp(-L). sumExpm1 evaluates it without subtracting nearly equal values,
+// which keeps tiny rates and long terms accurate where summing
+// pv*(1+rate)^j and the annuity of the payments would cancel.
+func sumBalances(rate float64, nper int, pv float64, first int, last int) float64 {
+	l := -math.Log1p(rate)
+	n := float64(nper)
+	if n*l < 700 {
+		return pv * sumExpm1(nper-last, last-first+1, l) / math.Expm1(n*l)
+	}
+
+	// A negative rate over a very long term: (1+rate)^-nper overflows, so
+	// divide through by it and sum the balances as pv*(exp(-j*l)-exp(-n*l)).
+	c := float64(last - first + 1)
+	sum := math.Exp(-float64(first)*l)*math.Expm1(-c*l)/math.Expm1(-l) - c*math.Exp(-n*l)
+	return pv * sum / -math.Expm1(-n*l)
+}
+
+// sumExpm1 returns expm1(a*l) + expm1((a+1)*l) + ... + expm1((a+count-1)*l),
+// written as (expm1(a*l)*expm1(count*l) + expm1(count*l) - count*expm1(l)) /
+// expm1(l). Both terms of the numerator have the same sign, and the second,
+// which is second order in l, comes from its Taylor series when count*l is
+// small.
+func sumExpm1(a int, count int, l float64) float64 {
+	c := float64(count)
+	em := math.Expm1(c * l)
+
+	var d float64
+	if math.Abs(c*l) < 1 {
+		p, q := c*l, l
+		for k := 2.0; ; k++ {
+			p *= c * l / k
+			q *= l / k
+			term := p - c*q
+			d += term
+			if math.Abs(term) <= math.Abs(d)*0x1p-53 {
+				break
+			}
+		}
+	} else {
+		d = em - c*math.Expm1(l)
+	}
+
+	return (math.Expm1(float64(a)*l)*em + d) / math.Expm1(l)
+}
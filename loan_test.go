@@ -0,0 +1,157 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLoan() {
+	loan := Loan{Rate: 0.08 / 12, Nper: 10, Pv: 1_000_000}
+	fmt.Printf("%.2f\n", loan.Pmt())
+	// Output: -103703.21
+}
+
+func TestTimingOf(t *testing.T) {
+	assert.Equal(t, EndOfPeriod, timingOf(false))
+	assert.Equal(t, BeginningOfPeriod, timingOf(true))
+	assert.Equal(t, EndOfPeriod, Loan{}.Timing)
+}
+
+func TestLoanValidate(t *testing.T) {
+	type testData struct {
+		loan     Loan
+		expected error
+	}
+
+	testCases := []testData{
+		{Loan{Rate: 0.1, Nper: 36, Pv: 800_000}, nil},
+		{Loan{Rate: 0.1, Nper: 36, Pv: 800_000, Timing: BeginningOfPeriod}, nil},
+		{Loan{Rate: -0.005, Nper: 36, Pv: 800_000}, nil},
+		{Loan{Rate: -1, Nper: 36, Pv: 800_000}, ErrNum},
+		{Loan{Rate: 0.1, Nper: 0, Pv: 800_000}, ErrNum},
+		{Loan{Rate: 0.1, Nper: 36, Pv: 800_000, Timing: 2}, ErrNum},
+		{Loan{Rate: math.NaN(), Nper: 36, Pv: 800_000}, ErrValue},
+		{Loan{Rate: 0.1, Nper: 36, Pv: math.Inf(1)}, ErrValue},
+	}
+	for _, testCase := range testCases {
+		err := testCase.loan.Validate()
+		if testCase.expected == nil {
+			assert.NoError(t, err, testCase)
+		} else {
+			assert.ErrorIs(t, err, testCase.expected, testCase)
+		}
+	}
+}
+
+func TestLoanPmt(t *testing.T) {
+	loan := Loan{Rate: 0.3, Nper: 36, Pv: 100_000, Fv: 1_000}
+	assert.InDelta(t, -30_002.396163, loan.Pmt(), DELTA)
+
+	loan.Timing = BeginningOfPeriod
+	assert.InDelta(t, -23_078.766279, loan.Pmt(), DELTA)
+
+	assert.Equal(t, 0.0, Loan{Rate: 0.3, Pv: 100_000}.Pmt())
+}
+
+func TestLoanIpmt(t *testing.T) {
+	loan := Loan{Rate: 0.1, Nper: 36, Pv: 800_000, Fv: 1_000}
+	assert.InDelta(t, -79_732.220588, loan.Ipmt(2), DELTA)
+
+	loan.Timing = BeginningOfPeriod
+	assert.InDelta(t, -72_483.836898, loan.Ipmt(2), DELTA)
+
+	assert.Equal(t, 0.0, loan.Ipmt(0))
+}
+
+func TestLoanPpmt(t *testing.T) {
+	loan := Loan{Rate: 0.1, Nper: 36, Pv: 800_000}
+	assert.InDelta(t, -7_630.520980, loan.Ppmt(12), DELTA)
+	assert.Equal(t, 0.0, loan.Ppmt(0))
+	assert.Equal(t, 0.0, loan.Ppmt(37))
+}
+
+func TestLoanFutureValue(t *testing.T) {
+	loan := Loan{Rate: 0.1, Nper: 12}
+	assert.InDelta(t, -213_842.837672, loan.FutureValue(10_000), DELTA)
+
+	loan.Fv = 1_000_000
+	assert.InDelta(t, -213_842.837672, loan.FutureValue(10_000), DELTA)
+}
+
+func TestLoanCumipmt(t *testing.T) {
+	loan := Loan{Rate: 0.1, Nper: 36, Pv: 800_000}
+	assert.InDelta(t, -537_857.728242, loan.Cumipmt(6, 12), DELTA)
+
+	loan.Timing = BeginningOfPeriod
+	assert.InDelta(t, -488_961.571129, loan.Cumipmt(6, 12), DELTA)
+
+	loan.Fv = 1_000_000
+	assert.InDelta(t, -488_961.571129, loan.Cumipmt(6, 12), DELTA)
+
+	t.Run("long terms", func(t *testing.T) {
+		loan := Loan{Rate: 0.3, Nper: 360, Pv: 800_000}
+		assert.InEpsilon(t, -85_599_999.999999997, loan.Cumipmt(1, 360), 1e-14)
+
+		loan = Loan{Rate: -0.5, Nper: 10_950, Pv: 800_000}
+		assert.Equal(t, 400_000.0, loan.Cumipmt(1, 1))
+		assert.InEpsilon(t, 800_000.0, loan.Cumipmt(1, 10_950), 1e-14)
+	})
+}
+
+func BenchmarkLoanCumipmt(b *testing.B) {
+	for _, nper := range []int{12, 420, 10_950} {
+		loan := Loan{Rate: 0.035 / 12, Nper: nper, Pv: 30_000_000}
+		b.Run(fmt.Sprintf("nper=%d", nper), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loan.Cumipmt(1, nper)
+			}
+		})
+	}
+}
+
+func TestExtremeInputs(t *testing.T) {
+	type testArgs struct {
+		rate float64
+		nper int
+		due  bool
+	}
+
+	type testData struct {
+		args testArgs
+		pmt  float64
+		ipmt float64
+		fv   float64
+	}
+
+	// Reference values computed with 60 significant digits from the exact
+	// binary value of each rate. pv is 10,000,000 (1,000,000 for rate 0.05),
+	// Ipmt is taken at nper/2 and Fv pays 1,000 per period.
+	testCases := []testData{
+		{testArgs{0.00001, 10_950, false}, -964.15888847544363, -51.377529168000493, 11_571_946.008264851},
+		{testArgs{0.00001, 10_950, true}, -964.14924698297375, -51.377015397846513, 11_572_061.727724934},
+		{testArgs{0.0001 / 365, 10_950, false}, -914.61268217906843, -1.3711406137876005, 10_966_439.932819663},
+		{testArgs{0.0001 / 365, 10_950, true}, -914.61243160031995, -1.3711402381327409, 10_966_442.937323755},
+		{testArgs{1e-10, 360, false}, -27_777.778279166669, -0.00050277778227763887, 360_000.00646200008},
+		{testArgs{1e-10, 360, true}, -27_777.778276388894, -0.00050277778222736116, 360_000.00649800006},
+		{testArgs{1e-15, 360, false}, -27_777.777777782791, -5.0277777777782278e-09, 360_000.00000006461},
+		{testArgs{1e-15, 360, true}, -27_777.777777782765, -5.0277777777782229e-09, 360_000.00000006496},
+		{testArgs{-0.00001, 10_950, false}, -864.14976517488424, 48.640712873125231, 10_371_832.635551313},
+		{testArgs{-0.00001, 10_950, true}, -864.15840675895186, 48.641199285118084, 10_371_728.917224959},
+		{testArgs{0.05, 1_200, false}, -50_000, -49_999.999999990796, 5.347968980553581e+29},
+		{testArgs{0.05, 1_200, true}, -47_619.047619047618, -47_619.047619038851, 5.6153674295812597e+29},
+	}
+	for _, testCase := range testCases {
+		args := testCase.args
+		pv := 10_000_000.0
+		if args.rate == 0.05 {
+			pv = 1_000_000.0
+		}
+		loan := Loan{Rate: args.rate, Nper: args.nper, Pv: pv, Timing: timingOf(args.due)}
+		assert.InEpsilon(t, testCase.pmt, loan.Pmt(), 1e-12, "Pmt %v", args)
+		assert.InEpsilon(t, testCase.ipmt, loan.Ipmt(args.nper/2), 1e-12, "Ipmt %v", args)
+		assert.InEpsilon(t, testCase.fv, Loan{Rate: args.rate, Nper: args.nper, Timing: timingOf(args.due)}.FutureValue(-1_000), 1e-12, "FutureValue %v", args)
+	}
+}
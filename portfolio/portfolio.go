@@ -0,0 +1,97 @@
+// Package portfolio evaluates large sets of loans concurrently on top of
+// xlsxfin.Loan.
+package portfolio
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/abetomo/xlsxfin"
+)
+
+// Result is the evaluation of one loan. Interest, Principal and Balances
+// have one element per period; Balances holds the balance still owed after
+// each payment, with the sign of Pv, so its last element is -Fv.
+//
+// Err is the loan's own error, from Loan.Validate or from the context if
+// the run was cancelled before the loan was evaluated; the other fields are
+// zero then.
+type Result struct {
+	Payment            float64
+	Interest           []float64
+	Principal          []float64
+	Balances           []float64
+	CumulativeInterest float64
+	Err                error
+}
+
+// Evaluator evaluates loans on Workers goroutines, or on GOMAXPROCS of them
+// when Workers is zero or less.
+type Evaluator struct {
+	Workers int
+}
+
+// Evaluate returns the results of loans in the same order. An invalid loan
+// only sets the Err of its own Result. If ctx is cancelled or its deadline
+// passes, the loans not yet evaluated get ctx.Err() and Evaluate returns it
+// as well once the workers have stopped.
+func (e Evaluator) Evaluate(ctx context.Context, loans []xlsxfin.Loan) ([]Result, error) {
+	workers := e.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]Result, len(loans))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i] = evaluate(loans[i])
+			}
+		}()
+	}
+
+	for i := range loans {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+func evaluate(loan xlsxfin.Loan) Result {
+	if err := loan.Validate(); err != nil {
+		return Result{Err: err}
+	}
+
+	// Under BeginningOfPeriod a closing balance of the schedule is owed at
+	// the start of the period and grows by Rate until its end.
+	growth := 1.0
+	if loan.Timing == xlsxfin.BeginningOfPeriod {
+		growth += loan.Rate
+	}
+
+	schedule := loan.Schedule()
+	result := Result{
+		Payment:            loan.Pmt(),
+		Interest:           make([]float64, loan.Nper),
+		Principal:          make([]float64, loan.Nper),
+		Balances:           make([]float64, loan.Nper),
+		CumulativeInterest: schedule.TotalInterest(),
+	}
+	for i, installment := range schedule {
+		result.Interest[i] = installment.Interest
+		result.Principal[i] = installment.Principal
+		result.Balances[i] = installment.ClosingBalance * growth
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/abetomo/xlsxfin"
+	"github.com/stretchr/testify/assert"
+)
+
+const DELTA = 0.000001
+
+func ExampleEvaluator() {
+	loans := []xlsxfin.Loan{
+		{Rate: 0.08 / 12, Nper: 10, Pv: 1_000_000},
+		{Rate: 0.08 / 12, Nper: 0, Pv: 1_000_000},
+	}
+	results, _ := Evaluator{Workers: 2}.Evaluate(context.Background(), loans)
+	fmt.Printf("%.2f %.2f\n", results[0].Payment, results[0].CumulativeInterest)
+	fmt.Println(results[1].Err)
+	// Output:
+	// -103703.21 -37032.09
+	// #NUM!
+}
+
+func TestEvaluate(t *testing.T) {
+	loans := make([]xlsxfin.Loan, 100)
+	for i := range loans {
+		loans[i] = xlsxfin.Loan{
+			Rate:   float64(i%5) / 1200,
+			Nper:   12 + i,
+			Pv:     float64(1_000_000 + 1_000*i),
+			Fv:     float64(i % 3 * 10_000),
+			Timing: xlsxfin.PaymentTiming(i % 2),
+		}
+	}
+	loans[7].Rate = -1
+	loans[9].Nper = 0
+
+	for _, workers := range []int{0, 1, 8} {
+		results, err := Evaluator{Workers: workers}.Evaluate(context.Background(), loans)
+		assert.NoError(t, err)
+		assert.Len(t, results, len(loans))
+
+		for i, result := range results {
+			loan := loans[i]
+			if i == 7 || i == 9 {
+				assert.ErrorIs(t, result.Err, xlsxfin.ErrNum, i)
+				assert.Nil(t, result.Balances, i)
+				continue
+			}
+
+			assert.NoError(t, result.Err, i)
+			assert.Equal(t, loan.Pmt(), result.Payment, i)
+			assert.Len(t, result.Interest, loan.Nper, i)
+			assert.Equal(t, loan.Ipmt(5), result.Interest[4], i)
+			assert.Equal(t, loan.Ppmt(5), result.Principal[4], i)
+			assert.InDelta(t, -loan.Fv, result.Balances[loan.Nper-1], DELTA, i)
+			for per := 1; per <= loan.Nper; per++ {
+				paid := loan
+				paid.Nper = per
+				assert.InDelta(t, -paid.FutureValue(result.Payment), result.Balances[per-1], DELTA, "%d %d", i, per)
+			}
+
+			sum := 0.0
+			for per := 1; per <= loan.Nper; per++ {
+				sum += loan.Ipmt(per)
+			}
+			assert.InDelta(t, sum, result.CumulativeInterest, DELTA, i)
+			if loan.Fv == 0 {
+				assert.InDelta(t, loan.Cumipmt(1, loan.Nper), result.CumulativeInterest, DELTA, i)
+			}
+		}
+	}
+}
+
+func TestEvaluateCancel(t *testing.T) {
+	loans := make([]xlsxfin.Loan, 10)
+	for i := range loans {
+		loans[i] = xlsxfin.Loan{Rate: 0.01, Nper: 12, Pv: 1_000}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := Evaluator{Workers: 4}.Evaluate(ctx, loans)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, results, len(loans))
+	for i, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled, i)
+		assert.Nil(t, result.Interest, i)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	_, err = Evaluator{}.Evaluate(ctx, loans)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
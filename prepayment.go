@@ -0,0 +1,145 @@
+package xlsxfin
+
+import (
+	"math"
+	"sort"
+)
+
+// PrepaymentPolicy decides what a prepayment shortens.
+type PrepaymentPolicy int
+
+const (
+	// ShortenTerm keeps the payment and ends the loan earlier (期間短縮型).
+	ShortenTerm PrepaymentPolicy = iota
+	// ReducePayment keeps the term and lowers the payment (返済額軽減型).
+	ReducePayment
+)
+
+// Prepayment repays Amount of principal early, together with the
+// installment of Period. Amount is positive; a prepayment larger than the
+// balance left only repays the balance.
+type Prepayment struct {
+	Period int
+	Amount float64
+	Policy PrepaymentPolicy
+}
+
+// PrepaymentResult is a schedule with prepayments applied. InterestSaved is
+// the interest no longer paid compared with Loan.Schedule, positive when
+// the prepayments save interest, and Nper is the period of the last
+// payment.
+type PrepaymentResult struct {
+	Schedule      Schedule
+	InterestSaved float64
+	Nper          int
+}
+
+// Prepay returns the loan's schedule with prepayments applied. The loan must
+// pass Validate and its balance must fall over the term, from a Pv above
+// the balance it closes at, and every prepayment must fall
+// within the term with an Amount of 0 or more; otherwise Prepay returns
+// ErrNum, or ErrValue for NaN or infinite amounts.
+//
+// The rows follow Schedule: interest is -Rate times the opening balance and
+// the balance closes at -Fv, or at -Fv/(1+Rate) under BeginningOfPeriod.
+// After a prepayment the rest of the loan is repaid as a level-payment
+// annuity of the closing balance. Under ReducePayment its payment is
+// recomputed as Pmt over the periods left; under ShortenTerm the payment is
+// kept and the loan ends as soon as it is repaid, with a smaller last
+// payment. Prepayments due after the loan has ended are ignored.
+func (l Loan) Prepay(prepayments []Prepayment) (PrepaymentResult, error) {
+	if err := l.Validate(); err != nil {
+		return PrepaymentResult{}, err
+	}
+	if l.Pv <= l.lastBalance() {
+		return PrepaymentResult{}, ErrNum
+	}
+	for _, prepayment := range prepayments {
+		if err := checkArgs(prepayment.Amount); err != nil {
+			return PrepaymentResult{}, err
+		}
+		if prepayment.Period < 1 || prepayment.Period > l.Nper || prepayment.Amount < 0 {
+			return PrepaymentResult{}, ErrNum
+		}
+		if prepayment.Policy != ShortenTerm && prepayment.Policy != ReducePayment {
+			return PrepaymentResult{}, ErrNum
+		}
+	}
+
+	sorted := make([]Prepayment, len(prepayments))
+	copy(sorted, prepayments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Period < sorted[j].Period })
+
+	// -Fv is -0 for the usual Fv of 0; adding 0 makes the schedule close at 0.
+	target := l.lastBalance() + 0
+	payment := l.Pmt()
+	maturity := l.Nper
+	schedule := make(Schedule, 0, l.Nper)
+	opening := l.Pv
+	cumulativeInterest := 0.0
+	next := 0
+	for per := 1; per <= maturity; per++ {
+		interest := 0.0
+		if !l.due() || per > 1 {
+			interest = -l.Rate * opening
+		}
+		installment := Installment{
+			Period:         per,
+			Payment:        payment,
+			Interest:       interest,
+			OpeningBalance: opening,
+		}
+
+		closing := opening + payment - interest
+		if per == maturity || closing <= target {
+			// The last payment, or a shortened term running out: repay
+			// exactly what is left.
+			closing = target
+			installment.Payment = target - opening + interest
+		}
+		installment.Principal = installment.Payment - interest
+
+		policy := ShortenTerm
+		for ; next < len(sorted) && sorted[next].Period == per; next++ {
+			prepayment := math.Min(sorted[next].Amount, closing-target)
+			installment.Prepayment -= prepayment
+			closing -= prepayment
+			policy = sorted[next].Policy
+		}
+		if installment.Prepayment != 0 && closing > target {
+			if policy == ReducePayment {
+				payment = newAnnuity(l.Rate, float64(maturity-per)).pmt(closing, -target, false)
+			} else {
+				maturity = per + remainingPeriods(l.Rate, payment, closing, target)
+			}
+		}
+
+		cumulativeInterest += interest
+		installment.ClosingBalance = closing
+		installment.CumulativeInterest = cumulativeInterest
+		schedule = append(schedule, installment)
+		opening = closing
+		if closing == target {
+			break
+		}
+	}
+
+	return PrepaymentResult{
+		Schedule:      schedule,
+		InterestSaved: cumulativeInterest - l.Schedule().TotalInterest(),
+		Nper:          len(schedule),
+	}, nil
+}
+
+// remainingPeriods returns how many payments of payment at the end of each
+// period repay balance down to target, as Excel's NPER rounded up. A
+// remainder within 1e-9 of a period does not take another one.
+func remainingPeriods(rate float64, payment float64, balance float64, target float64) int {
+	var nper float64
+	if rate == 0 {
+		nper = (target - balance) / payment
+	} else {
+		nper = math.Log((target*rate+payment)/(balance*rate+payment)) / math.Log1p(rate)
+	}
+	return int(math.Ceil(nper - 1e-9))
+}
@@ -0,0 +1,133 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLoan_Prepay() {
+	loan := Loan{Rate: 0.015 / 12, Nper: 420, Pv: 30_000_000}
+	for _, policy := range []PrepaymentPolicy{ShortenTerm, ReducePayment} {
+		result, _ := loan.Prepay([]Prepayment{{Period: 60, Amount: 3_000_000, Policy: policy}})
+		fmt.Printf("%d %.0f %.0f\n", result.Nper, result.Schedule[60].Payment, result.InterestSaved)
+	}
+	// Output:
+	// 371 -91855 1562045
+	// 420 -81502 727298
+}
+
+func TestLoanPrepay(t *testing.T) {
+	loan := Loan{Rate: 0.015 / 12, Nper: 420, Pv: 30_000_000}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Loan{Rate: 0.01, Nper: 0, Pv: 1_000}.Prepay(nil)
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = Loan{Rate: 0.01, Nper: 12, Pv: -1_000}.Prepay(nil)
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = loan.Prepay([]Prepayment{{Period: 0, Amount: 1_000}})
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = loan.Prepay([]Prepayment{{Period: 421, Amount: 1_000}})
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = loan.Prepay([]Prepayment{{Period: 12, Amount: -1_000}})
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = loan.Prepay([]Prepayment{{Period: 12, Amount: 1_000, Policy: 2}})
+		assert.ErrorIs(t, err, ErrNum)
+		_, err = loan.Prepay([]Prepayment{{Period: 12, Amount: math.NaN()}})
+		assert.ErrorIs(t, err, ErrValue)
+	})
+
+	t.Run("without prepayments", func(t *testing.T) {
+		for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+			loan := Loan{Rate: 0.035 / 12, Nper: 360, Pv: 30_000_000, Fv: 1_000_000, Timing: timing}
+			result, err := loan.Prepay(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, 360, result.Nper)
+			assert.InDelta(t, 0, result.InterestSaved, 1e-3)
+			expected := loan.Schedule()
+			for i, installment := range result.Schedule {
+				assert.InDelta(t, expected[i].Payment, installment.Payment, 1e-6, "%v %d", timing, i)
+				assert.InDelta(t, expected[i].Interest, installment.Interest, 1e-6, "%v %d", timing, i)
+				assert.InDelta(t, expected[i].ClosingBalance, installment.ClosingBalance, 1e-3, "%v %d", timing, i)
+			}
+			assert.Equal(t, loan.lastBalance(), result.Schedule[359].ClosingBalance, timing)
+		}
+	})
+
+	check := func(t *testing.T, result PrepaymentResult) {
+		schedule := result.Schedule
+		assert.Len(t, schedule, result.Nper)
+		for i, installment := range schedule {
+			assert.Equal(t, i+1, installment.Period)
+			assert.InDelta(t, installment.Interest+installment.Principal, installment.Payment, DELTA, i)
+			assert.InDelta(t, installment.OpeningBalance+installment.Principal+installment.Prepayment, installment.ClosingBalance, DELTA, i)
+			if i > 0 {
+				assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, i)
+			}
+		}
+		assert.Equal(t, 0.0, schedule[len(schedule)-1].ClosingBalance)
+		assert.False(t, math.Signbit(schedule[len(schedule)-1].ClosingBalance))
+		assert.InDelta(t, result.InterestSaved, schedule.TotalInterest()-loan.Schedule().TotalInterest(), DELTA)
+
+		totals := schedule.Totals()
+		assert.InDelta(t, -30_000_000, totals.Principal+totals.Prepayment, DELTA)
+	}
+
+	t.Run("shorten term", func(t *testing.T) {
+		result, err := loan.Prepay([]Prepayment{{Period: 60, Amount: 3_000_000, Policy: ShortenTerm}})
+		assert.NoError(t, err)
+		check(t, result)
+		assert.Equal(t, -3_000_000.0, result.Schedule[59].Prepayment)
+		assert.Equal(t, loan.Pmt(), result.Schedule[60].Payment)
+		assert.Equal(t, loan.Pmt(), result.Schedule[result.Nper-2].Payment)
+		assert.Greater(t, result.Schedule[result.Nper-1].Payment, loan.Pmt())
+		assert.Greater(t, result.InterestSaved, 0.0)
+
+		// The periods left after the prepayment are NPER of the balance.
+		balance := result.Schedule[59].ClosingBalance
+		rate := loan.Rate
+		nper := math.Log(-loan.Pmt()/(-loan.Pmt()-balance*rate)) / math.Log1p(rate)
+		assert.Equal(t, 60+int(math.Ceil(nper)), result.Nper)
+	})
+
+	t.Run("reduce payment", func(t *testing.T) {
+		result, err := loan.Prepay([]Prepayment{{Period: 60, Amount: 3_000_000, Policy: ReducePayment}})
+		assert.NoError(t, err)
+		check(t, result)
+		assert.Equal(t, 420, result.Nper)
+		balance := result.Schedule[59].ClosingBalance
+		assert.InDelta(t, PmtF64(loan.Rate, 360, balance, 0, false), result.Schedule[60].Payment, DELTA)
+		assert.InDelta(t, PmtF64(loan.Rate, 360, balance, 0, false), result.Schedule[419].Payment, DELTA)
+		assert.Greater(t, result.InterestSaved, 0.0)
+	})
+
+	t.Run("several prepayments", func(t *testing.T) {
+		result, err := loan.Prepay([]Prepayment{
+			{Period: 120, Amount: 1_000_000, Policy: ReducePayment},
+			{Period: 24, Amount: 2_000_000, Policy: ShortenTerm},
+			{Period: 24, Amount: 500_000, Policy: ShortenTerm},
+		})
+		assert.NoError(t, err)
+		check(t, result)
+		assert.Equal(t, -2_500_000.0, result.Schedule[23].Prepayment)
+		assert.Equal(t, -1_000_000.0, result.Schedule[119].Prepayment)
+		assert.Less(t, result.Nper, 420)
+
+		// Reducing the payment keeps the term already shortened.
+		balance := result.Schedule[119].ClosingBalance
+		assert.InDelta(t, PmtF64(loan.Rate, result.Nper-120, balance, 0, false), result.Schedule[120].Payment, DELTA)
+	})
+
+	t.Run("repaid in full", func(t *testing.T) {
+		result, err := loan.Prepay([]Prepayment{
+			{Period: 12, Amount: 100_000_000},
+			{Period: 24, Amount: 1_000_000},
+		})
+		assert.NoError(t, err)
+		check(t, result)
+		assert.Equal(t, 12, result.Nper)
+		assert.InDelta(t, -loan.Schedule()[11].ClosingBalance, result.Schedule[11].Prepayment, DELTA)
+	})
+}
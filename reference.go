@@ -0,0 +1,344 @@
+package xlsxfin
+
+import (
+	"math"
+	"math/big"
+)
+
+// Reference evaluates the float64 functions again with math/big.Float at
+// Prec bits of precision, as a slow but accurate check of their results.
+// Prec defaults to 256 bits when zero. Intermediate values carry 64 guard
+// bits on top of Prec, so for ordinary loans every returned digit is
+// correct; raise Prec when results cancel heavily, for example an Ipmt
+// near the end of a term of thousands of periods.
+//
+// Each method takes the same arguments as the function of the same name
+// and returns 0 for the same invalid arguments.
+type Reference struct {
+	Prec uint
+}
+
+// Accuracy is the error of a float64 result against a reference value.
+// ULPs is the distance measured in units in the last place of the reference
+// rounded to float64; Relative is the distance divided by the reference.
+type Accuracy struct {
+	ULPs     float64
+	Relative float64
+}
+
+// Compare measures got against the reference value want, typically the
+// float64 function and the Reference method of the same name.
+func Compare(got float64, want *big.Float) Accuracy {
+	if math.IsNaN(got) || math.IsInf(got, 0) {
+		return Accuracy{ULPs: math.Inf(1), Relative: math.Inf(1)}
+	}
+
+	prec := want.Prec() + 64
+	diff := new(big.Float).SetPrec(prec).Sub(new(big.Float).SetPrec(prec).SetFloat64(got), want)
+	diff.Abs(diff)
+
+	wantFloat64, _ := want.Float64()
+	if math.IsInf(wantFloat64, 0) {
+		return Accuracy{ULPs: math.Inf(1), Relative: 1}
+	}
+	ulp := math.Nextafter(math.Abs(wantFloat64), math.Inf(1)) - math.Abs(wantFloat64)
+	ulps, _ := new(big.Float).SetPrec(prec).Quo(diff, big.NewFloat(ulp)).Float64()
+
+	if want.Sign() == 0 {
+		if diff.Sign() == 0 {
+			return Accuracy{ULPs: ulps}
+		}
+		return Accuracy{ULPs: ulps, Relative: math.Inf(1)}
+	}
+	relative, _ := new(big.Float).SetPrec(prec).Quo(diff, new(big.Float).Abs(want)).Float64()
+	return Accuracy{ULPs: ulps, Relative: relative}
+}
+
+func (r Reference) prec() uint {
+	if r.Prec == 0 {
+		return 256
+	}
+	return r.Prec
+}
+
+func (r Reference) float(x float64) floatDecimal {
+	return floatDecimal{new(big.Float).SetPrec(r.prec() + 64).SetFloat64(x)}
+}
+
+func (r Reference) result(x floatDecimal) *big.Float {
+	return new(big.Float).SetPrec(r.prec()).Set(x.f)
+}
+
+type floatDecimal struct {
+	f *big.Float
+}
+
+func (x floatDecimal) new() *big.Float {
+	return new(big.Float).SetPrec(x.f.Prec())
+}
+
+func (x floatDecimal) Add(y floatDecimal) floatDecimal {
+	return floatDecimal{x.new().Add(x.f, y.f)}
+}
+
+func (x floatDecimal) Sub(y floatDecimal) floatDecimal {
+	return floatDecimal{x.new().Sub(x.f, y.f)}
+}
+
+func (x floatDecimal) Mul(y floatDecimal) floatDecimal {
+	return floatDecimal{x.new().Mul(x.f, y.f)}
+}
+
+func (x floatDecimal) Div(y floatDecimal) floatDecimal {
+	return floatDecimal{x.new().Quo(x.f, y.f)}
+}
+
+func (x floatDecimal) Sign() int {
+	return x.f.Sign()
+}
+
+func (x floatDecimal) FromInt(n int64) floatDecimal {
+	return floatDecimal{x.new().SetInt64(n)}
+}
+
+func (x floatDecimal) FromFloat(f float64) floatDecimal {
+	return floatDecimal{x.new().SetFloat64(f)}
+}
+
+func (x floatDecimal) trunc() floatDecimal {
+	i, _ := x.f.Int(nil)
+	return floatDecimal{x.new().SetInt(i)}
+}
+
+// log returns the natural logarithm of x > 0. x = m*2^e with m in [0.5, 1),
+// and log(m) = 2*atanh((m-1)/(m+1)) converges quickly as |(m-1)/(m+1)| <= 1/3.
+func (x floatDecimal) log() floatDecimal {
+	m := x.new()
+	e := x.f.MantExp(m)
+	ln2 := floatDecimal{x.new().SetFloat64(0.5)}.atanhLog().Mul(x.FromInt(-1))
+	return floatDecimal{m}.atanhLog().Add(ln2.Mul(x.FromInt(int64(e))))
+}
+
+func (x floatDecimal) atanhLog() floatDecimal {
+	one := x.FromInt(1)
+	z := x.Sub(one).Div(x.Add(one))
+	z2 := z.Mul(z)
+	sum := z
+	term := z
+	epsilon := x.new().SetMantExp(big.NewFloat(1), -int(x.f.Prec()))
+	for k := int64(3); ; k += 2 {
+		term = term.Mul(z2)
+		next := term.Div(x.FromInt(k))
+		if new(big.Float).Abs(next.f).Cmp(epsilon) < 0 {
+			break
+		}
+		sum = sum.Add(next)
+	}
+	return sum.Mul(x.FromInt(2))
+}
+
+// exp halves x until it is below 2^-8, sums the Taylor series and squares
+// the result back.
+func (x floatDecimal) exp() floatDecimal {
+	if x.Sign() == 0 {
+		return x.FromInt(1)
+	}
+	halvings := x.f.MantExp(nil) + 8
+	if halvings < 0 {
+		halvings = 0
+	}
+	y := floatDecimal{x.new().SetMantExp(x.f, -halvings)}
+
+	epsilon := x.new().SetMantExp(big.NewFloat(1), -int(x.f.Prec()))
+	sum := x.FromInt(1)
+	term := x.FromInt(1)
+	for k := int64(1); ; k++ {
+		term = term.Mul(y).Div(x.FromInt(k))
+		if new(big.Float).Abs(term.f).Cmp(epsilon) < 0 {
+			break
+		}
+		sum = sum.Add(term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum = sum.Mul(sum)
+	}
+	return sum
+}
+
+func (x floatDecimal) pow(y floatDecimal) floatDecimal {
+	if y.f.IsInt() {
+		n, _ := y.f.Int64()
+		return decimalPow(x, int(n))
+	}
+	return x.log().Mul(y).exp()
+}
+
+func (r Reference) Pmt(rate float64, nper int, pv float64, fv float64, paymentFlag bool) *big.Float {
+	return r.result(PmtDecimal(r.float(rate), nper, r.float(pv), r.float(fv), timingOf(paymentFlag)))
+}
+
+func (r Reference) Ipmt(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) *big.Float {
+	return r.result(IpmtDecimal(r.float(rate), per, nper, r.float(pv), r.float(fv), timingOf(paymentFlag)))
+}
+
+func (r Reference) Ispmt(rate float64, per int, nper int, pv float64) *big.Float {
+	if nper == 0 {
+		return r.result(r.float(0))
+	}
+	n := r.float(float64(nper))
+	ratio := r.float(float64(per)).Div(n).Sub(n.FromInt(1))
+	return r.result(r.float(pv).Mul(r.float(rate)).Mul(ratio))
+}
+
+func (r Reference) Fv(rate float64, nper int, pmt float64, pv float64, paymentFlag bool) *big.Float {
+	return r.result(FvDecimal(r.float(rate), nper, r.float(pmt), r.float(pv), timingOf(paymentFlag)))
+}
+
+func (r Reference) Fvschedule(principal float64, rates []float64) *big.Float {
+	fv := r.float(principal)
+	for _, rate := range rates {
+		fv = fv.Mul(fv.FromInt(1).Add(fv.FromFloat(rate)))
+	}
+	return r.result(fv)
+}
+
+func (r Reference) FvRates(rates []float64, pmt float64, pv float64, paymentFlag bool) *big.Float {
+	balance := r.float(pv)
+	payment := r.float(pmt)
+	for _, rate := range rates {
+		growth := balance.FromInt(1).Add(balance.FromFloat(rate))
+		if paymentFlag {
+			balance = balance.Add(payment).Mul(growth)
+		} else {
+			balance = balance.Mul(growth).Add(payment)
+		}
+	}
+	return r.result(balance.FromInt(0).Sub(balance))
+}
+
+func (r Reference) Pduration(rate float64, pv float64, fv float64) *big.Float {
+	if rate <= 0.0 || pv <= 0 || fv <= 0 {
+		return r.result(r.float(0))
+	}
+	growth := r.float(1).Add(r.float(rate)).log()
+	return r.result(r.float(fv).log().Sub(r.float(pv).log()).Div(growth))
+}
+
+func (r Reference) Rri(nper int, pv float64, fv float64) *big.Float {
+	if nper <= 0 || pv == 0 {
+		return r.result(r.float(0))
+	}
+	ratio := r.float(fv).Div(r.float(pv))
+	if ratio.Sign() < 0 {
+		return r.result(r.float(0))
+	}
+	if ratio.Sign() == 0 {
+		return r.result(r.float(-1))
+	}
+	return r.result(ratio.log().Div(ratio.FromInt(int64(nper))).exp().Sub(ratio.FromInt(1)))
+}
+
+func (r Reference) Ppmt(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) *big.Float {
+	return r.result(PpmtDecimal(r.float(rate), per, nper, r.float(pv), r.float(fv), timingOf(paymentFlag)))
+}
+
+func (r Reference) Cumipmt(rate float64, nper int, pv float64, start int, end int, paymentFlag bool) *big.Float {
+	return r.result(CumipmtDecimal(r.float(rate), nper, r.float(pv), start, end, timingOf(paymentFlag)))
+}
+
+func (r Reference) dollar(amount float64, fraction float64, toDecimal bool) *big.Float {
+	fraction = math.Trunc(fraction)
+	if fraction < 1 {
+		return r.result(r.float(0))
+	}
+
+	x := r.float(amount)
+	integer := x.trunc()
+	decimal := x.Sub(integer)
+	f := r.float(fraction)
+	denominator := r.float(dollarDenominator(fraction))
+	if toDecimal {
+		return r.result(integer.Add(decimal.Mul(denominator).Div(f)))
+	}
+	return r.result(integer.Add(decimal.Mul(f).Div(denominator)))
+}
+
+func (r Reference) DollarDe(fractionalDollar float64, fraction float64) *big.Float {
+	return r.dollar(fractionalDollar, fraction, true)
+}
+
+func (r Reference) DollarFr(decimalDollar float64, fraction float64) *big.Float {
+	return r.dollar(decimalDollar, fraction, false)
+}
+
+func (r Reference) pmtFrac(rate float64, nper float64, pv float64, fv float64, paymentFlag bool) floatDecimal {
+	if nper == 0 {
+		return r.float(0)
+	}
+	n := r.float(nper)
+	if rate == 0 {
+		return n.FromInt(0).Sub(r.float(pv).Add(r.float(fv)).Div(n))
+	}
+
+	rt := r.float(rate)
+	onePlusRate := rt.FromInt(1).Add(rt)
+	pvif := onePlusRate.pow(n)
+	pmt := rt.FromInt(0).Sub(r.float(pv).Mul(pvif).Add(r.float(fv)).Mul(rt).Div(pvif.Sub(rt.FromInt(1))))
+	if !paymentFlag {
+		return pmt
+	}
+	return pmt.Div(onePlusRate)
+}
+
+func (r Reference) ipmtFrac(rate float64, per float64, nper float64, pv float64, fv float64, paymentFlag bool) floatDecimal {
+	if nper == 0 || per == 0 || rate <= -1 || rate == 0 || paymentFlag && per == 1 {
+		return r.float(0)
+	}
+
+	rt := r.float(rate)
+	onePlusRate := rt.FromInt(1).Add(rt)
+	pmt := r.pmtFrac(rate, nper, pv, fv, false)
+	n := onePlusRate.pow(r.float(per - 1))
+	m := n.Sub(rt.FromInt(1))
+	ip := rt.FromInt(0).Sub(r.float(pv).Mul(n).Mul(rt).Add(pmt.Mul(m)))
+	if !paymentFlag {
+		return ip
+	}
+	return ip.Div(onePlusRate)
+}
+
+func (r Reference) PmtFrac(rate float64, nper float64, pv float64, fv float64, paymentFlag bool) *big.Float {
+	return r.result(r.pmtFrac(rate, nper, pv, fv, paymentFlag))
+}
+
+func (r Reference) IpmtFrac(rate float64, per float64, nper float64, pv float64, fv float64, paymentFlag bool) *big.Float {
+	return r.result(r.ipmtFrac(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func (r Reference) PpmtFrac(rate float64, per float64, nper float64, pv float64, fv float64, paymentFlag bool) *big.Float {
+	if per < 1 || per >= nper+1 {
+		return r.result(r.float(0))
+	}
+	pmt := r.pmtFrac(rate, nper, pv, fv, paymentFlag)
+	return r.result(pmt.Sub(r.ipmtFrac(rate, per, nper, pv, fv, paymentFlag)))
+}
+
+func (r Reference) FvFrac(rate float64, nper float64, pmt float64, pv float64, paymentFlag bool) *big.Float {
+	n := r.float(nper)
+	if rate == 0 {
+		return r.result(n.FromInt(0).Sub(r.float(pv).Add(r.float(pmt).Mul(n))))
+	}
+
+	rt := r.float(rate)
+	onePlusRate := rt.FromInt(1).Add(rt)
+	term := onePlusRate.pow(n)
+	annuity := term.Sub(rt.FromInt(1)).Mul(r.float(pmt))
+	if paymentFlag {
+		annuity = annuity.Mul(onePlusRate)
+	}
+	return r.result(rt.FromInt(0).Sub(r.float(pv).Mul(term).Add(annuity.Div(rt))))
+}
+
+func (r Reference) CumipmtFrac(rate float64, nper float64, pv float64, start float64, end float64, paymentFlag bool) *big.Float {
+	return r.Cumipmt(rate, int(math.Trunc(nper)), pv, int(math.Trunc(start)), int(math.Trunc(end)), paymentFlag)
+}
@@ -0,0 +1,142 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCompare() {
+	want := Reference{}.Pmt(0.00001, 10_950, 10_000_000, 0, false)
+	accuracy := Compare(PmtF64(0.00001, 10_950, 10_000_000, 0, false), want)
+	fmt.Println(want.Text('g', 20), accuracy.ULPs <= 4)
+	// Output: -964.15888847544361445 true
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		assert.Equal(t, Accuracy{}, Compare(1.5, big.NewFloat(1.5)))
+		assert.Equal(t, Accuracy{}, Compare(0, new(big.Float)))
+	})
+
+	t.Run("one ULP", func(t *testing.T) {
+		actual := Compare(math.Nextafter(-1, -2), big.NewFloat(-1))
+		assert.Equal(t, 1.0, actual.ULPs)
+		assert.Equal(t, 0x1p-52, actual.Relative)
+	})
+
+	t.Run("half ULP", func(t *testing.T) {
+		want := new(big.Float).SetPrec(256).SetFloat64(1)
+		want.Add(want, new(big.Float).SetMantExp(big.NewFloat(1), -53))
+		actual := Compare(1, want)
+		assert.InDelta(t, 0.5, actual.ULPs, 1e-12)
+	})
+
+	t.Run("reference is 0", func(t *testing.T) {
+		actual := Compare(1e-300, new(big.Float))
+		assert.True(t, math.IsInf(actual.Relative, 1))
+	})
+}
+
+func TestReferenceLogExp(t *testing.T) {
+	for _, x := range []float64{1e-300, 1e-10, 0.3, 0.5, 1, 2, 10, 12_345.678, 1e300} {
+		actual, _ := Reference{}.float(x).log().f.Float64()
+		assert.InDelta(t, math.Log(x), actual, 1e-15*math.Max(1, math.Abs(math.Log(x))), "log %v", x)
+	}
+	for _, x := range []float64{-700, -10, -1e-10, 0, 1e-10, 0.3, 1, 10, 700} {
+		actual, _ := Reference{}.float(x).exp().f.Float64()
+		assert.InEpsilon(t, math.Exp(x), actual, 1e-15, "exp %v", x)
+	}
+}
+
+func TestReference(t *testing.T) {
+	r := Reference{}
+	toFloat64 := func(f *big.Float) float64 {
+		v, _ := f.Float64()
+		return v
+	}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		assert.Equal(t, 0, r.Pmt(0.1, 0, 1_000, 0, false).Sign())
+		assert.Equal(t, 0, r.Ipmt(-1, 2, 10, 1_000, 0, false).Sign())
+		assert.Equal(t, 0, r.Ispmt(0.1, 2, 0, 1_000).Sign())
+		assert.Equal(t, 0, r.Pduration(0, 1_000, 2_000).Sign())
+		assert.Equal(t, 0, r.Rri(10, 1_000, -2_000).Sign())
+		assert.Equal(t, 0, r.Cumipmt(0.1, 10, 1_000, 5, 4, false).Sign())
+		assert.Equal(t, 0, r.DollarDe(1.02, 0.5).Sign())
+		assert.Equal(t, 0, r.PpmtFrac(0.1, 11.5, 10, 1_000, 0, false).Sign())
+	})
+
+	t.Run("exact values", func(t *testing.T) {
+		// Computed with 80 significant digits from the binary value of
+		// each rate.
+		assert.InEpsilon(t, -964.15888847544361445, toFloat64(r.Pmt(0.00001, 10_950, 10_000_000, 0, false)), 1e-16)
+		assert.InEpsilon(t, -51.377015397846513033, toFloat64(r.Ipmt(0.00001, 5_475, 10_950, 10_000_000, 0, true)), 1e-16)
+		assert.InEpsilon(t, 5.3479689805535806876e+29, toFloat64(r.Fv(0.05, 1_200, -1_000, 0, false)), 1e-16)
+		assert.InEpsilon(t, -49_999.999999990794013, toFloat64(r.Ipmt(0.05, 600, 1_200, 1_000_000, 0, false)), 1e-16)
+	})
+
+	t.Run("Frac agrees at integral periods", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			assert.Equal(t, r.Pmt(0.01, 36, 800_000, 1_000, paymentFlag).String(), r.PmtFrac(0.01, 36, 800_000, 1_000, paymentFlag).String())
+			assert.InEpsilon(t, toFloat64(r.Ipmt(0.01, 5, 36, 800_000, 1_000, paymentFlag)), toFloat64(r.IpmtFrac(0.01, 5, 36, 800_000, 1_000, paymentFlag)), 1e-30)
+			assert.InEpsilon(t, toFloat64(r.Fv(0.01, 36, -1_000, 800_000, paymentFlag)), toFloat64(r.FvFrac(0.01, 36, -1_000, 800_000, paymentFlag)), 1e-30)
+		}
+	})
+}
+
+// TestFloat64Accuracy bounds the error of the float64 functions against
+// Reference over ordinary arguments. Ppmt is the difference of Pmt and Ipmt,
+// so it loses a few digits to cancellation on long terms.
+func TestFloat64Accuracy(t *testing.T) {
+	r := Reference{}
+	const maxULPs = 64
+
+	for _, paymentFlag := range []bool{false, true} {
+		for _, rate := range []float64{-0.005, 0.00001, 0.0025, 0.08 / 12, 0.03} {
+			for _, nper := range []int{1, 12, 360} {
+				args := fmt.Sprint(rate, nper, paymentFlag)
+				accuracy := Compare(PmtF64(rate, nper, 800_000, 1_000, paymentFlag), r.Pmt(rate, nper, 800_000, 1_000, paymentFlag))
+				assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "Pmt %v", args)
+
+				accuracy = Compare(FvF64(rate, nper, -1_000, 800_000, paymentFlag), r.Fv(rate, nper, -1_000, 800_000, paymentFlag))
+				assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "Fv %v", args)
+
+				accuracy = Compare(PmtFrac(rate, float64(nper)+0.5, 800_000, 1_000, paymentFlag), r.PmtFrac(rate, float64(nper)+0.5, 800_000, 1_000, paymentFlag))
+				assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "PmtFrac %v", args)
+
+				for _, per := range []int{1, (nper + 1) / 2, nper} {
+					accuracy = Compare(IpmtF64(rate, per, nper, 800_000, 1_000, paymentFlag), r.Ipmt(rate, per, nper, 800_000, 1_000, paymentFlag))
+					assert.LessOrEqual(t, accuracy.Relative, 1e-12, "Ipmt %v %v", per, args)
+
+					accuracy = Compare(PpmtF64(rate, per, nper, 800_000, 1_000, paymentFlag), r.Ppmt(rate, per, nper, 800_000, 1_000, paymentFlag))
+					assert.LessOrEqual(t, accuracy.Relative, 1e-9, "Ppmt %v %v", per, args)
+				}
+
+				for _, period := range [][2]int{{1, nper}, {1, 1}, {nper, nper}, {(nper + 1) / 2, nper}} {
+					accuracy = Compare(CumipmtF64(rate, nper, 800_000, period[0], period[1], paymentFlag), r.Cumipmt(rate, nper, 800_000, period[0], period[1], paymentFlag))
+					assert.LessOrEqual(t, accuracy.Relative, 1e-13, "Cumipmt %v %v", period, args)
+				}
+			}
+		}
+	}
+
+	rates := []float64{0.05, -0.02, 0.1, 0.031}
+	accuracy := Compare(FvscheduleFloat64(10_000, rates), r.Fvschedule(10_000, rates))
+	assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "Fvschedule")
+	accuracy = Compare(FvRatesF64(rates, -1_000, 10_000, true), r.FvRates(rates, -1_000, 10_000, true))
+	assert.LessOrEqual(t, accuracy.Relative, 1e-12, "FvRates")
+	accuracy = Compare(PdurationF64(0.025, 2_000, 2_200), r.Pduration(0.025, 2_000, 2_200))
+	assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "Pduration")
+	accuracy = Compare(RriF64(96, 10_000, 11_000), r.Rri(96, 10_000, 11_000))
+	assert.LessOrEqual(t, accuracy.Relative, 1e-12, "Rri")
+	accuracy = Compare(IspmtF64(0.1, 4, 12, 800_000), r.Ispmt(0.1, 4, 12, 800_000))
+	assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "Ispmt")
+	accuracy = Compare(DollarDe(1.02, 16), r.DollarDe(1.02, 16))
+	assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "DollarDe")
+	accuracy = Compare(DollarFr(1.125, 16), r.DollarFr(1.125, 16))
+	assert.LessOrEqual(t, accuracy.ULPs, float64(maxULPs), "DollarFr")
+}
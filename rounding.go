@@ -0,0 +1,76 @@
+package xlsxfin
+
+import (
+	"math"
+	"math/big"
+)
+
+// RoundingMode selects how the Round, Money, Currency and Of functions round
+// their results. The zero value rounds half away from zero like Excel's
+// ROUND, which the int functions without a mode argument always use.
+type RoundingMode int32
+
+const (
+	RoundHalfAwayFromZero RoundingMode = iota
+	RoundHalfEven
+	RoundHalfUp
+	RoundFloor
+	RoundCeiling
+	RoundTruncate
+)
+
+func (mode RoundingMode) Round(f float64) float64 {
+	switch mode {
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundHalfUp:
+		return math.Floor(f + .5)
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeiling:
+		return math.Ceil(f)
+	case RoundTruncate:
+		return math.Trunc(f)
+	default:
+		return math.Round(f)
+	}
+}
+
+func (mode RoundingMode) roundInt(f float64) int {
+	return int(mode.Round(f))
+}
+
+func (mode RoundingMode) roundRat(x *big.Rat) *big.Int {
+	quo, rem := new(big.Int).DivMod(x.Num(), x.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	up := false
+	switch mode {
+	case RoundFloor:
+	case RoundCeiling:
+		up = true
+	case RoundTruncate:
+		up = x.Sign() < 0
+	default:
+		switch rem.Lsh(rem, 1).Cmp(x.Denom()) {
+		case -1:
+		case 1:
+			up = true
+		default:
+			switch mode {
+			case RoundHalfEven:
+				up = quo.Bit(0) == 1
+			case RoundHalfUp:
+				up = true
+			default:
+				up = x.Sign() > 0
+			}
+		}
+	}
+	if up {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
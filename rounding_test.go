@@ -0,0 +1,109 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleRoundingMode() {
+	fmt.Println(PmtOf[int](RoundTruncate, 0.3, 36, 100_000, 0, BeginningOfPeriod))
+	// Output: -23078
+}
+
+func TestRoundingModeRound(t *testing.T) {
+	type testData struct {
+		mode     RoundingMode
+		expected []float64
+	}
+
+	values := []float64{2.5, -2.5, 3.5, -3.5, 2.4, -2.6}
+	testCases := []testData{
+		{RoundHalfAwayFromZero, []float64{3, -3, 4, -4, 2, -3}},
+		{RoundHalfEven, []float64{2, -2, 4, -4, 2, -3}},
+		{RoundHalfUp, []float64{3, -2, 4, -3, 2, -3}},
+		{RoundFloor, []float64{2, -3, 3, -4, 2, -3}},
+		{RoundCeiling, []float64{3, -2, 4, -3, 3, -2}},
+		{RoundTruncate, []float64{2, -2, 3, -3, 2, -2}},
+	}
+	for _, testCase := range testCases {
+		for i, value := range values {
+			assert.Equal(t, testCase.expected[i], testCase.mode.Round(value), testCase.mode, value)
+		}
+	}
+}
+
+func TestRoundingModeRoundRat(t *testing.T) {
+	values := []*big.Rat{
+		big.NewRat(5, 2),
+		big.NewRat(-5, 2),
+		big.NewRat(7, 2),
+		big.NewRat(-7, 2),
+		big.NewRat(12, 5),
+		big.NewRat(-13, 5),
+		big.NewRat(-3, 1),
+	}
+	modes := []RoundingMode{
+		RoundHalfAwayFromZero,
+		RoundHalfEven,
+		RoundHalfUp,
+		RoundFloor,
+		RoundCeiling,
+		RoundTruncate,
+	}
+	for _, mode := range modes {
+		for _, value := range values {
+			f, _ := value.Float64()
+			expected := int64(mode.Round(f))
+			assert.Equal(t, expected, mode.roundRat(value).Int64(), mode, value.String())
+		}
+	}
+}
+
+func TestRoundingModeExplicit(t *testing.T) {
+	assert.Equal(t, -3, RoundHalfAwayFromZero.roundInt(-2.5))
+	assert.Equal(t, Money(-3), roundRat(big.NewRat(-5, 2), RoundHalfAwayFromZero))
+	assert.Equal(t, Money(-2), roundRat(big.NewRat(-5, 2), RoundHalfUp))
+
+	assert.Equal(t, -30_003, PmtOf[int](RoundFloor, 0.3, 36, 100_000, 0, EndOfPeriod))
+	assert.Equal(t, Money(-30_003), PmtMoney(RoundFloor, big.NewRat(3, 10), 36, 100_000, 0, false))
+	assert.Equal(t, Money(-30_002), PmtMoney(RoundHalfAwayFromZero, big.NewRat(3, 10), 36, 100_000, 0, false))
+	// The int functions without a mode argument round half away from zero.
+	assert.Equal(t, -30_002, Pmt(0.3, 36, 100_000, 0, false))
+	assert.Equal(t, -30_003, PmtRound(RoundFloor, 0.3, 36, 100_000, 0, false))
+}
+
+func TestRoundingModeIntFunctions(t *testing.T) {
+	rates := []float64{0.1, 0.2, 0.15}
+	modes := []RoundingMode{
+		RoundHalfAwayFromZero,
+		RoundHalfEven,
+		RoundHalfUp,
+		RoundFloor,
+		RoundCeiling,
+		RoundTruncate,
+	}
+	for _, mode := range modes {
+		expected := func(f float64) int { return int(mode.Round(f)) }
+		assert.Equal(t, expected(PmtFloat64(0.3, 36, 100_000, 0, false)), PmtRound(mode, 0.3, 36, 100_000, 0, false), mode)
+		assert.Equal(t, expected(IpmtFloat64(0.3, 2, 36, 100_000, 0, false)), IpmtRound(mode, 0.3, 2, 36, 100_000, 0, false), mode)
+		assert.Equal(t, expected(IspmtFloat64(0.3, 2, 36, 100_000)), IspmtRound(mode, 0.3, 2, 36, 100_000), mode)
+		assert.Equal(t, expected(FvFloat64(0.3, 36, -30_000, 100_000, false)), FvRound(mode, 0.3, 36, -30_000, 100_000, false), mode)
+		assert.Equal(t, expected(FvscheduleFloat64(100_000.5, rates)), FvscheduleRound(mode, 100_000.5, rates), mode)
+		assert.Equal(t, expected(FvRatesFloat64(rates, -1_000, 100_000, true)), FvRatesRound(mode, rates, -1_000, 100_000, true), mode)
+		assert.Equal(t, expected(PpmtFloat64(0.3, 2, 36, 100_000, 0, false)), PpmtRound(mode, 0.3, 2, 36, 100_000, 0, false), mode)
+		assert.Equal(t, expected(CumipmtFloat64(0.3, 36, 100_000, 1, 12, false)), CumipmtRound(mode, 0.3, 36, 100_000, 1, 12, false), mode)
+
+		got, err := CumipmtRoundE(mode, 0.3, 36, 100_000, 1, 12, false)
+		assert.NoError(t, err)
+		assert.Equal(t, CumipmtRound(mode, 0.3, 36, 100_000, 1, 12, false), got, mode)
+	}
+
+	_, err := PmtRoundE(RoundFloor, 0.3, 0, 100_000, 0, false)
+	assert.ErrorIs(t, err, ErrNum)
+	got, err := PmtRoundE(RoundFloor, 0.3, 36, 100_000, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, -30_003, got)
+}
@@ -0,0 +1,250 @@
+package xlsxfin
+
+import "math"
+
+// Installment is one row of an amortization schedule. Amounts follow
+// Excel's signs: for a loan with a positive Pv, Payment, Interest,
+// Principal and Prepayment are negative while the balances stay positive,
+// and ClosingBalance is OpeningBalance plus Principal and Prepayment.
+// Prepayment is the principal repaid early on top of Payment, and is only
+// set by Loan.Prepay. UnpaidInterest is the interest owed but not yet paid
+// after the period, positive like the balances, and is only set by
+// AdjustableLoan.
+type Installment struct {
+	Period             int
+	Payment            float64
+	Interest           float64
+	Principal          float64
+	Prepayment         float64
+	OpeningBalance     float64
+	ClosingBalance     float64
+	CumulativeInterest float64
+	UnpaidInterest     float64
+}
+
+// Schedule is an amortization schedule with one Installment per period.
+type Schedule []Installment
+
+// Schedule returns the loan's amortization schedule, or nil if the loan
+// fails Validate.
+//
+// Interest and Principal equal Ipmt and Ppmt of the same period, and the
+// balances come from a closed form rather than a running sum, so the last
+// ClosingBalance is exactly -Fv. Under BeginningOfPeriod the first payment
+// is due before any interest accrues, so as in Excel its Interest is 0 and
+// the whole payment is principal, and the balance after the last payment is
+// -Fv/(1+Rate), which grows to -Fv by the end of the term.
+func (l Loan) Schedule() Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	a := newAnnuity(l.Rate, float64(l.Nper))
+	due := l.due()
+	payment := a.pmt(l.Pv, l.Fv, due)
+
+	schedule := make(Schedule, l.Nper)
+	opening := l.Pv
+	cumulativeInterest := 0.0
+	for i := range schedule {
+		per := i + 1
+		interest := a.ipmt(float64(per), l.Pv, l.Fv, due)
+		cumulativeInterest += interest
+
+		closing := a.balance(float64(per), l.Pv, l.Fv)
+		if due {
+			closing /= 1 + l.Rate
+		}
+
+		schedule[i] = Installment{
+			Period:             per,
+			Payment:            payment,
+			Interest:           interest,
+			Principal:          payment - interest,
+			OpeningBalance:     opening,
+			ClosingBalance:     closing,
+			CumulativeInterest: cumulativeInterest,
+		}
+		opening = closing
+	}
+	return schedule
+}
+
+// lastBalance returns the balance left after the last payment: -Fv, or
+// under BeginningOfPeriod -Fv/(1+Rate), which grows to -Fv by the end of
+// the term.
+func (l Loan) lastBalance() float64 {
+	if l.due() {
+		return -l.Fv / (1 + l.Rate)
+	}
+	return -l.Fv
+}
+
+// TotalInterest returns the interest paid over the whole schedule.
+func (s Schedule) TotalInterest() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	return s[len(s)-1].CumulativeInterest
+}
+
+// Totals are the sums of a schedule's columns.
+type Totals struct {
+	Payment    float64
+	Interest   float64
+	Principal  float64
+	Prepayment float64
+}
+
+// Totals adds up the schedule, for example to compare level payments with
+// equal principal for the same loan.
+func (s Schedule) Totals() Totals {
+	var totals Totals
+	for _, installment := range s {
+		totals.Payment += installment.Payment
+		totals.Interest += installment.Interest
+		totals.Principal += installment.Principal
+		totals.Prepayment += installment.Prepayment
+	}
+	return totals
+}
+
+// TrueUp selects the installments of a RoundedSchedule that absorb the
+// rounding differences, so that the balance closes exactly.
+type TrueUp int
+
+const (
+	// FinalTrueUp adjusts the last installment alone.
+	FinalTrueUp TrueUp = iota
+	// FirstAndFinalTrueUp adjusts the first installment by as many whole
+	// units as it can and lets the last installment settle what is left,
+	// so both ends may differ from the level payment.
+	FirstAndFinalTrueUp
+)
+
+// RoundedSchedule returns the loan's schedule with every amount rounded to
+// the currency's minor units with mode, as lenders issue them, or nil if
+// the loan fails Validate or settling the rounding would cancel a payment
+// or reverse its sign, as it can when the payment is only a few minor units.
+//
+// The payment is rounded once and each period's interest is rounded from
+// the rounded balance, so every row reconciles exactly: ClosingBalance is
+// OpeningBalance plus Principal and Principal is Payment minus Interest. The
+// differences left by rounding are settled so that the last ClosingBalance
+// is the rounded last balance of Schedule: -Fv, or -Fv/(1+Rate) under
+// BeginningOfPeriod. With FinalTrueUp the last installment settles them.
+// With FirstAndFinalTrueUp the first installment is adjusted by the whole
+// units that leave the smallest difference at the end and the last one
+// settles the rest. A unit paid first moves the last balance by about
+// (1+Rate)^(Nper-1) units and the rounded interest of every later period
+// follows it, so the first installment alone cannot close the balance in
+// general.
+func (l Loan) RoundedSchedule(currency Currency, mode RoundingMode, trueUp TrueUp) Schedule {
+	if l.Validate() != nil {
+		return nil
+	}
+
+	closing := currency.Money(l.lastBalance(), mode)
+	payment := currency.Money(l.Pmt(), mode)
+	rows := roundedInstallments(l, currency, mode, payment, 0)
+
+	if trueUp == FirstAndFinalTrueUp {
+		// A unit paid in the first period lowers the last balance by about
+		// (1+Rate)^(Nper-1) units, which estimates the adjustment. The
+		// rounded interest of the later periods follows the balances, so the
+		// residual is not monotonic in the adjustment; the adjustments around
+		// the estimate are tried and the one leaving the smallest residual is
+		// kept.
+		residual := func(rows []roundedInstallment) Money {
+			r := rows[len(rows)-1].closing - closing
+			if r < 0 {
+				return -r
+			}
+			return r
+		}
+		growth := math.Pow(1+l.Rate, float64(l.Nper-1))
+		estimate := -Money(math.Round(float64(rows[len(rows)-1].closing-closing) / growth))
+		best := residual(rows)
+		for step := Money(0); step <= 2*firstTrueUpRadius && best > 0; step++ {
+			// estimate, estimate+1, estimate-1, estimate+2, ...
+			adjustment := estimate + (step+1)/2
+			if step%2 == 0 {
+				adjustment = estimate - step/2
+			}
+			if adjustment == 0 || reversed(payment, payment+adjustment) {
+				continue
+			}
+			candidate := roundedInstallments(l, currency, mode, payment, adjustment)
+			if r := residual(candidate); r < best {
+				rows, best = candidate, r
+			}
+		}
+	}
+
+	last := &rows[len(rows)-1]
+	last.principal = closing - last.opening
+	last.payment = last.principal + last.interest
+	last.closing = closing
+	if reversed(payment, last.payment) {
+		return nil
+	}
+
+	return newRoundedSchedule(currency, rows)
+}
+
+// reversed reports whether an adjusted payment no longer has the sign of the
+// level payment, so that nothing is paid or the lender pays the borrower.
+func reversed(level, adjusted Money) bool {
+	return level < 0 && adjusted >= 0 || level > 0 && adjusted <= 0
+}
+
+// firstTrueUpRadius is how many units either side of the estimated
+// adjustment RoundedSchedule tries with FirstAndFinalTrueUp.
+const firstTrueUpRadius = 4
+
+type roundedInstallment struct {
+	payment   Money
+	interest  Money
+	principal Money
+	opening   Money
+	closing   Money
+}
+
+func newRoundedSchedule(currency Currency, rows []roundedInstallment) Schedule {
+	schedule := make(Schedule, len(rows))
+	cumulativeInterest := Money(0)
+	for i, row := range rows {
+		cumulativeInterest += row.interest
+		schedule[i] = Installment{
+			Period:             i + 1,
+			Payment:            currency.Float64(row.payment),
+			Interest:           currency.Float64(row.interest),
+			Principal:          currency.Float64(row.principal),
+			OpeningBalance:     currency.Float64(row.opening),
+			ClosingBalance:     currency.Float64(row.closing),
+			CumulativeInterest: currency.Float64(cumulativeInterest),
+		}
+	}
+	return schedule
+}
+
+// roundedInstallments runs the loan with a level payment in minor units,
+// the first of which is changed by adjustment.
+func roundedInstallments(l Loan, currency Currency, mode RoundingMode, payment Money, adjustment Money) []roundedInstallment {
+	rows := make([]roundedInstallment, l.Nper)
+	balance := currency.Money(l.Pv, mode)
+	for i := range rows {
+		row := roundedInstallment{payment: payment, opening: balance}
+		if i == 0 {
+			row.payment += adjustment
+		}
+		if !l.due() || i > 0 {
+			row.interest = currency.Money(-l.Rate*currency.Float64(balance), mode)
+		}
+		row.principal = row.payment - row.interest
+		row.closing = row.opening + row.principal
+		rows[i] = row
+		balance = row.closing
+	}
+	return rows
+}
@@ -0,0 +1,192 @@
+package xlsxfin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLoan_Schedule() {
+	loan := Loan{Rate: 0.08 / 12, Nper: 3, Pv: 1_000_000}
+	for _, installment := range loan.Schedule() {
+		fmt.Printf("%d %.2f %.2f %.2f %.2f\n", installment.Period, installment.Payment, installment.Interest, installment.Principal, installment.ClosingBalance)
+	}
+	// Output:
+	// 1 -337787.62 -6666.67 -331120.95 668879.05
+	// 2 -337787.62 -4459.19 -333328.43 335550.62
+	// 3 -337787.62 -2237.00 -335550.62 0.00
+}
+
+func TestLoanSchedule(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		assert.Nil(t, Loan{Rate: 0.1, Nper: 0, Pv: 1_000}.Schedule())
+		assert.Nil(t, Loan{Rate: -1, Nper: 12, Pv: 1_000}.Schedule())
+	})
+
+	for _, timing := range []PaymentTiming{EndOfPeriod, BeginningOfPeriod} {
+		for _, rate := range []float64{0, -0.005, 0.035 / 12, 0.1} {
+			for _, fv := range []float64{0, 100_000} {
+				loan := Loan{Rate: rate, Nper: 36, Pv: 800_000, Fv: fv, Timing: timing}
+				args := fmt.Sprint(loan)
+				schedule := loan.Schedule()
+				assert.Len(t, schedule, 36, args)
+
+				for i, installment := range schedule {
+					per := i + 1
+					assert.Equal(t, per, installment.Period, args)
+					assert.Equal(t, loan.Pmt(), installment.Payment, args)
+					assert.Equal(t, loan.Ipmt(per), installment.Interest, "%v %d", args, per)
+					assert.Equal(t, loan.Ppmt(per), installment.Principal, "%v %d", args, per)
+					if timing == BeginningOfPeriod && per == 1 {
+						assert.Equal(t, 0.0, installment.Interest, args)
+						assert.Equal(t, installment.Payment, installment.Principal, args)
+					}
+					assert.InDelta(t, installment.OpeningBalance+installment.Principal, installment.ClosingBalance, DELTA, "%v %d", args, per)
+					if per > 1 {
+						assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, args)
+					}
+				}
+
+				assert.Equal(t, loan.Pv, schedule[0].OpeningBalance, args)
+				if timing == EndOfPeriod {
+					assert.InDelta(t, -fv, schedule[35].ClosingBalance, DELTA, args)
+				} else {
+					assert.InDelta(t, -fv/(1+rate), schedule[35].ClosingBalance, DELTA, args)
+				}
+				if fv == 0 && rate > -1 {
+					assert.InDelta(t, loan.Cumipmt(1, 36), schedule.TotalInterest(), DELTA, args)
+				}
+			}
+		}
+	}
+
+	t.Run("last balance is exact", func(t *testing.T) {
+		schedule := Loan{Rate: 0.035 / 12, Nper: 420, Pv: 30_000_000, Fv: 1_000_000}.Schedule()
+		assert.Equal(t, -1_000_000.0, schedule[419].ClosingBalance)
+	})
+}
+
+func TestScheduleTotalInterest(t *testing.T) {
+	assert.Equal(t, 0.0, Schedule(nil).TotalInterest())
+}
+
+func ExampleLoan_RoundedSchedule() {
+	jpy, _ := LookupCurrency("JPY")
+	loan := Loan{Rate: 0.08 / 12, Nper: 3, Pv: 1_000_000}
+	for _, installment := range loan.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp) {
+		fmt.Println(installment.Period, installment.Payment, installment.Interest, installment.Principal, installment.ClosingBalance)
+	}
+	// Output:
+	// 1 -337788 -6667 -331121 668879
+	// 2 -337788 -4459 -333329 335550
+	// 3 -337787 -2237 -335550 0
+}
+
+func TestLoanRoundedSchedule(t *testing.T) {
+	jpy, _ := LookupCurrency("JPY")
+	usd, _ := LookupCurrency("USD")
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Nil(t, Loan{Rate: 0.1, Nper: 0, Pv: 1_000}.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp))
+	})
+
+	loans := []Loan{
+		{Rate: 0.035 / 12, Nper: 420, Pv: 30_000_000},
+		{Rate: 0.1, Nper: 36, Pv: 800_000, Timing: BeginningOfPeriod},
+		{Rate: 0.01 / 12, Nper: 35, Pv: 1_000_000, Fv: 100_000},
+		{Rate: 0.15 / 12, Nper: 360, Pv: 50_000_000},
+		{Rate: 0, Nper: 7, Pv: 1_000_000},
+		{Rate: -0.001, Nper: 24, Pv: 1_000_000, Fv: 10_000, Timing: BeginningOfPeriod},
+	}
+	for _, currency := range []Currency{jpy, usd} {
+		for _, mode := range []RoundingMode{RoundHalfAwayFromZero, RoundHalfEven, RoundFloor, RoundTruncate} {
+			for _, trueUp := range []TrueUp{FinalTrueUp, FirstAndFinalTrueUp} {
+				for _, loan := range loans {
+					args := fmt.Sprint(currency.Code, mode, trueUp, loan)
+					schedule := loan.RoundedSchedule(currency, mode, trueUp)
+					exact := loan.Schedule()
+					assert.Len(t, schedule, loan.Nper, args)
+
+					payment := currency.Money(loan.Pmt(), mode)
+					totalInterest := Money(0)
+					for i, installment := range schedule {
+						for _, amount := range []float64{installment.Payment, installment.Interest, installment.Principal, installment.ClosingBalance} {
+							assert.Equal(t, currency.Round(amount, RoundHalfAwayFromZero), amount, args)
+						}
+						assert.Equal(t, currency.Money(installment.Payment, RoundHalfAwayFromZero), currency.Money(installment.Interest, RoundHalfAwayFromZero)+currency.Money(installment.Principal, RoundHalfAwayFromZero), "%v %d", args, i)
+						assert.Equal(t, currency.Money(installment.ClosingBalance, RoundHalfAwayFromZero), currency.Money(installment.OpeningBalance, RoundHalfAwayFromZero)+currency.Money(installment.Principal, RoundHalfAwayFromZero), "%v %d", args, i)
+						if i > 0 {
+							assert.Equal(t, schedule[i-1].ClosingBalance, installment.OpeningBalance, args)
+						}
+						if i > 0 && i < loan.Nper-1 {
+							assert.Equal(t, payment, currency.Money(installment.Payment, RoundHalfAwayFromZero), "%v %d", args, i)
+						}
+						assert.InDelta(t, exact[i].Interest, installment.Interest, 0.01*loan.Pv, "%v %d", args, i)
+						totalInterest += currency.Money(installment.Interest, RoundHalfAwayFromZero)
+					}
+
+					assert.Equal(t, currency.Money(loan.Pv, mode), currency.Money(schedule[0].OpeningBalance, RoundHalfAwayFromZero), args)
+					assert.Equal(t, currency.Money(exact[loan.Nper-1].ClosingBalance, mode), currency.Money(schedule[loan.Nper-1].ClosingBalance, RoundHalfAwayFromZero), args)
+					assert.Equal(t, totalInterest, currency.Money(schedule.TotalInterest(), RoundHalfAwayFromZero), args)
+					if trueUp == FinalTrueUp {
+						assert.Equal(t, payment, currency.Money(schedule[0].Payment, RoundHalfAwayFromZero), args)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("first true-up leaves the last payment level", func(t *testing.T) {
+		schedule := Loan{Rate: 0.01 / 12, Nper: 35, Pv: 1_000_000}.RoundedSchedule(jpy, RoundHalfAwayFromZero, FirstAndFinalTrueUp)
+		assert.Equal(t, -29_003.0, schedule[0].Payment)
+		assert.Equal(t, -29_002.0, schedule[1].Payment)
+		assert.Equal(t, -29_002.0, schedule[34].Payment)
+		assert.Equal(t, 0.0, schedule[34].ClosingBalance)
+	})
+	t.Run("first and final true-up keeps the smallest final adjustment", func(t *testing.T) {
+		// The rounded interest makes the residual jump around as the first
+		// payment changes, so a fixed-point iteration cycles on this loan.
+		loan := Loan{Rate: 0.1, Nper: 36, Pv: 800_000}
+		schedule := loan.RoundedSchedule(usd, RoundHalfAwayFromZero, FirstAndFinalTrueUp)
+		payment := usd.Money(loan.Pmt(), RoundHalfAwayFromZero)
+		assert.NotEqual(t, payment, usd.Money(schedule[0].Payment, RoundHalfAwayFromZero))
+
+		abs := func(m Money) Money {
+			if m < 0 {
+				return -m
+			}
+			return m
+		}
+		// The last installment settles the residual, so it differs from the
+		// level payment by exactly that.
+		residual := abs(usd.Money(schedule[35].Payment, RoundHalfAwayFromZero) - payment)
+		closing := usd.Money(loan.lastBalance(), RoundHalfAwayFromZero)
+		for adjustment := Money(-10); adjustment <= 10; adjustment++ {
+			rows := roundedInstallments(loan, usd, RoundHalfAwayFromZero, payment, adjustment)
+			assert.LessOrEqual(t, residual, abs(rows[35].closing-closing), adjustment)
+		}
+	})
+	t.Run("first and final true-up adjusts both ends", func(t *testing.T) {
+		schedule := Loan{Rate: 0.03 / 12, Nper: 420, Pv: 30_000_000}.RoundedSchedule(jpy, RoundHalfAwayFromZero, FirstAndFinalTrueUp)
+		assert.Equal(t, -115_470.0, schedule[0].Payment)
+		assert.Equal(t, -115_455.0, schedule[1].Payment)
+		assert.Equal(t, -115_441.0, schedule[419].Payment)
+		assert.Equal(t, 0.0, schedule[419].ClosingBalance)
+	})
+	t.Run("reversed payment", func(t *testing.T) {
+		loan := Loan{Rate: 0.001 / 12, Nper: 420, Pv: 12_345.67}
+		assert.Nil(t, loan.RoundedSchedule(jpy, RoundHalfAwayFromZero, FinalTrueUp))
+		assert.Nil(t, loan.RoundedSchedule(jpy, RoundHalfAwayFromZero, FirstAndFinalTrueUp))
+	})
+}
+
+func TestScheduleTotals(t *testing.T) {
+	assert.Equal(t, Totals{}, Schedule(nil).Totals())
+
+	loan := Loan{Rate: 0.08 / 12, Nper: 10, Pv: 1_000_000}
+	totals := loan.Schedule().Totals()
+	assert.InDelta(t, loan.Pmt()*10, totals.Payment, DELTA)
+	assert.InDelta(t, loan.Cumipmt(1, 10), totals.Interest, DELTA)
+	assert.InDelta(t, -1_000_000, totals.Principal, DELTA)
+}
@@ -2,124 +2,196 @@ package xlsxfin
 
 import "math"
 
-func round(f float64) int {
-	return int(math.Floor(f + .5))
+func PmtF64(rate float64, nper int, pv float64, fv float64, paymentFlag bool) float64 {
+	return Loan{rate, nper, pv, fv, timingOf(paymentFlag)}.Pmt()
 }
 
 func PmtFloat64(rate float64, nper int, pv int, fv int, paymentFlag bool) float64 {
-	if nper == 0 {
-		return 0
-	}
-	if rate == 0.0 {
-		return -float64(pv+fv) / float64(nper)
-	}
+	return PmtF64(rate, nper, float64(pv), float64(fv), paymentFlag)
+}
 
-	pvif := math.Pow(1.0+rate, float64(nper))
-	pmt := (rate / (pvif - 1)) * -(float64(pv)*pvif + float64(fv))
+func Pmt(rate float64, nper int, pv int, fv int, paymentFlag bool) int {
+	return PmtRound(RoundHalfAwayFromZero, rate, nper, pv, fv, paymentFlag)
+}
 
-	if !paymentFlag {
-		return pmt
-	}
-	return pmt / (1 + rate)
+func PmtRound(mode RoundingMode, rate float64, nper int, pv int, fv int, paymentFlag bool) int {
+	return mode.roundInt(PmtFloat64(rate, nper, pv, fv, paymentFlag))
 }
 
-func Pmt(rate float64, nper int, pv int, fv int, paymentFlag bool) int {
-	return round(PmtFloat64(rate, nper, pv, fv, paymentFlag))
+func IpmtF64(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) float64 {
+	return Loan{rate, nper, pv, fv, timingOf(paymentFlag)}.Ipmt(per)
 }
 
 func IpmtFloat64(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) float64 {
+	return IpmtF64(rate, per, nper, float64(pv), float64(fv), paymentFlag)
+}
+
+func Ipmt(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) int {
+	return IpmtRound(RoundHalfAwayFromZero, rate, per, nper, pv, fv, paymentFlag)
+}
+
+func IpmtRound(mode RoundingMode, rate float64, per int, nper int, pv int, fv int, paymentFlag bool) int {
+	return mode.roundInt(IpmtFloat64(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func IspmtF64(rate float64, per int, nper int, pv float64) float64 {
 	if nper == 0 {
 		return 0.0
 	}
+	return pv * rate * (float64(per)/float64(nper) - 1)
+}
 
-	if per == 0 {
-		return 0.0
-	}
+func IspmtFloat64(rate float64, per int, nper int, pv int) float64 {
+	return IspmtF64(rate, per, nper, float64(pv))
+}
 
-	if rate < 0 {
-		return 0.0
+func Ispmt(rate float64, per int, nper int, pv int) int {
+	return IspmtRound(RoundHalfAwayFromZero, rate, per, nper, pv)
+}
+
+func IspmtRound(mode RoundingMode, rate float64, per int, nper int, pv int) int {
+	return mode.roundInt(IspmtFloat64(rate, per, nper, pv))
+}
+
+func FvF64(rate float64, nper int, pmt float64, pv float64, paymentFlag bool) float64 {
+	return Loan{rate, nper, pv, 0, timingOf(paymentFlag)}.FutureValue(pmt)
+}
+
+func FvFloat64(rate float64, nper int, pmt float64, pv int, paymentFlag bool) float64 {
+	return FvF64(rate, nper, pmt, float64(pv), paymentFlag)
+}
+
+func Fv(rate float64, nper int, pmt float64, pv int, paymentFlag bool) int {
+	return FvRound(RoundHalfAwayFromZero, rate, nper, pmt, pv, paymentFlag)
+}
+
+func FvRound(mode RoundingMode, rate float64, nper int, pmt float64, pv int, paymentFlag bool) int {
+	return mode.roundInt(FvFloat64(rate, nper, pmt, pv, paymentFlag))
+}
+
+func FvscheduleFloat64(principal float64, rates []float64) float64 {
+	fv := principal
+	for _, rate := range rates {
+		fv *= 1.0 + rate
 	}
+	return fv
+}
 
-	pmt := PmtFloat64(rate, nper, pv, fv, false)
-	perSub1Float64 := float64(per - 1)
+func Fvschedule(principal float64, rates []float64) int {
+	return FvscheduleRound(RoundHalfAwayFromZero, principal, rates)
+}
+
+func FvscheduleRound(mode RoundingMode, principal float64, rates []float64) int {
+	return mode.roundInt(FvscheduleFloat64(principal, rates))
+}
 
-	n := 0.0
-	if math.Abs(rate) > 0.5 {
-		n = math.Pow(1.0+rate, perSub1Float64)
-	} else {
-		n = math.Exp(perSub1Float64 * math.Log(1.0+rate))
+func FvRatesF64(rates []float64, pmt float64, pv float64, paymentFlag bool) float64 {
+	balance := pv
+	for _, rate := range rates {
+		if paymentFlag {
+			balance = (balance + pmt) * (1.0 + rate)
+		} else {
+			balance = balance*(1.0+rate) + pmt
+		}
 	}
+	return -balance
+}
+
+func FvRatesFloat64(rates []float64, pmt float64, pv int, paymentFlag bool) float64 {
+	return FvRatesF64(rates, pmt, float64(pv), paymentFlag)
+}
+
+func FvRates(rates []float64, pmt float64, pv int, paymentFlag bool) int {
+	return FvRatesRound(RoundHalfAwayFromZero, rates, pmt, pv, paymentFlag)
+}
 
-	m := math.Exp(perSub1Float64*math.Log(1.0+rate)) - 1
+func FvRatesRound(mode RoundingMode, rates []float64, pmt float64, pv int, paymentFlag bool) int {
+	return mode.roundInt(FvRatesFloat64(rates, pmt, pv, paymentFlag))
+}
 
-	ip := -(float64(pv)*n*rate + pmt*m)
-	if !paymentFlag {
-		return ip
+func PdurationF64(rate float64, pv float64, fv float64) float64 {
+	if rate <= 0.0 || pv <= 0 || fv <= 0 {
+		return 0.0
 	}
-	return ip / (1.0 + rate)
+	return (math.Log(fv) - math.Log(pv)) / math.Log1p(rate)
 }
 
-func Ipmt(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) int {
-	return round(IpmtFloat64(rate, per, nper, pv, fv, paymentFlag))
+func Pduration(rate float64, pv int, fv int) float64 {
+	return PdurationF64(rate, float64(pv), float64(fv))
 }
 
-func FvFloat64(rate float64, nper int, pmt float64, pv int, paymentFlag bool) float64 {
-	pvFloat64 := float64(pv)
-	nperFloat64 := float64(nper)
-	if rate == 0 {
-		return -(pvFloat64 + pmt*nperFloat64)
+func RriF64(nper int, pv float64, fv float64) float64 {
+	if nper <= 0 || pv == 0 {
+		return 0.0
 	}
-	term := math.Pow(1.0+rate, nperFloat64)
-	if paymentFlag {
-		return -(pvFloat64*term + (pmt*(1+rate)*(term-1))/rate)
+
+	rri := math.Pow(fv/pv, 1.0/float64(nper)) - 1
+	if math.IsNaN(rri) {
+		return 0.0
 	}
-	return -(pvFloat64*term + (pmt*(term-1))/rate)
+	return rri
 }
 
-func Fv(rate float64, nper int, pmt float64, pv int, paymentFlag bool) int {
-	return round(FvFloat64(rate, nper, pmt, pv, paymentFlag))
+func Rri(nper int, pv int, fv int) float64 {
+	return RriF64(nper, float64(pv), float64(fv))
+}
+
+func PpmtF64(rate float64, per int, nper int, pv float64, fv float64, paymentFlag bool) float64 {
+	return Loan{rate, nper, pv, fv, timingOf(paymentFlag)}.Ppmt(per)
 }
 
 func PpmtFloat64(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) float64 {
-	if per < 1 || per >= nper+1 {
-		return 0
-	}
-	pmt := PmtFloat64(rate, nper, pv, fv, paymentFlag)
-	ipmt := IpmtFloat64(rate, per, nper, pv, fv, paymentFlag)
-	return pmt - ipmt
+	return PpmtF64(rate, per, nper, float64(pv), float64(fv), paymentFlag)
 }
 
 func Ppmt(rate float64, per int, nper int, pv int, fv int, paymentFlag bool) int {
-	return round(PpmtFloat64(rate, per, nper, pv, fv, paymentFlag))
+	return PpmtRound(RoundHalfAwayFromZero, rate, per, nper, pv, fv, paymentFlag)
+}
+
+func PpmtRound(mode RoundingMode, rate float64, per int, nper int, pv int, fv int, paymentFlag bool) int {
+	return mode.roundInt(PpmtFloat64(rate, per, nper, pv, fv, paymentFlag))
+}
+
+func CumipmtF64(rate float64, nper int, pv float64, start int, end int, paymentFlag bool) float64 {
+	return Loan{rate, nper, pv, 0, timingOf(paymentFlag)}.Cumipmt(start, end)
 }
 
 func CumipmtFloat64(rate float64, nper int, pv int, start int, end int, paymentFlag bool) float64 {
-	if rate <= 0.0 || nper <= 0 || pv <= 0 {
-		return 0.0
+	return CumipmtF64(rate, nper, float64(pv), start, end, paymentFlag)
+}
+
+func Cumipmt(rate float64, nper int, pv int, start int, end int, paymentFlag bool) int {
+	return CumipmtRound(RoundHalfAwayFromZero, rate, nper, pv, start, end, paymentFlag)
+}
+
+func CumipmtRound(mode RoundingMode, rate float64, nper int, pv int, start int, end int, paymentFlag bool) int {
+	return mode.roundInt(CumipmtFloat64(rate, nper, pv, start, end, paymentFlag))
+}
+
+func dollarDenominator(fraction float64) float64 {
+	denominator := 1.0
+	for denominator < fraction {
+		denominator *= 10
 	}
+	return denominator
+}
 
-	if start < 1 || end < 1 || start > end {
+func DollarDe(fractionalDollar float64, fraction float64) float64 {
+	fraction = math.Trunc(fraction)
+	if fraction < 1 {
 		return 0.0
 	}
 
-	pmt := PmtFloat64(rate, nper, pv, 0, paymentFlag)
-	interest := 0.0
-	if start == 1 {
-		if !paymentFlag {
-			interest = -float64(pv)
-		}
-		start++
-	}
-	for i := start; i <= end; i++ {
-		if paymentFlag {
-			interest += FvFloat64(rate, i-2, pmt, pv, true) - pmt
-		} else {
-			interest += FvFloat64(rate, i-1, pmt, pv, false)
-		}
-	}
-	return interest * rate
+	integer, decimal := math.Modf(fractionalDollar)
+	return integer + decimal*dollarDenominator(fraction)/fraction
 }
 
-func Cumipmt(rate float64, nper int, pv int, start int, end int, paymentFlag bool) int {
-	return round(CumipmtFloat64(rate, nper, pv, start, end, paymentFlag))
+func DollarFr(decimalDollar float64, fraction float64) float64 {
+	fraction = math.Trunc(fraction)
+	if fraction < 1 {
+		return 0.0
+	}
+
+	integer, decimal := math.Modf(decimalDollar)
+	return integer + decimal*fraction/dollarDenominator(fraction)
 }
@@ -9,6 +9,32 @@ import (
 
 const DELTA = 0.0001
 
+func ExamplePmtF64() {
+	v := PmtF64(0.01, 12, 1_234.56, 0, false)
+	fmt.Printf("%.2f\n", v)
+	// Output: -109.69
+}
+
+func TestPmtF64(t *testing.T) {
+	t.Run("fractional pv and fv", func(t *testing.T) {
+		assert.InDelta(t, -109.689161, PmtF64(0.01, 12, 1_234.56, 0, false), DELTA)
+		assert.InDelta(t, -108.642163, PmtF64(0.01, 12, 1_234.56, 0.5, true), DELTA)
+	})
+
+	t.Run("chains with FvF64", func(t *testing.T) {
+		fv := FvF64(0.01, 12, -100.25, 0, false)
+		assert.InDelta(t, -100.25, PmtF64(0.01, 12, 0, fv, false), DELTA)
+	})
+
+	t.Run("matches PmtFloat64", func(t *testing.T) {
+		for _, paymentFlag := range []bool{false, true} {
+			expected := PmtFloat64(0.3, 36, 100_000, 1_000, paymentFlag)
+			actual := PmtF64(0.3, 36, 100_000, 1_000, paymentFlag)
+			assert.Equal(t, expected, actual, paymentFlag)
+		}
+	})
+}
+
 func ExamplePmtFloat64() {
 	v := PmtFloat64(0.3, 36, 100_000, 0, false)
 	fmt.Println(v)
@@ -191,10 +217,18 @@ func TestPmt(t *testing.T) {
 	})
 }
 
+func TestIpmtF64(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		expected := IpmtFloat64(0.01, 3, 12, 123_456, 50, paymentFlag) / 100
+		actual := IpmtF64(0.01, 3, 12, 1_234.56, 0.5, paymentFlag)
+		assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+	}
+}
+
 func ExampleIpmtFloat64() {
 	v := IpmtFloat64(0.1, 2, 36, 800_000, 0, false)
 	fmt.Println(v)
-	// Output: -79732.55489453014
+	// Output: -79732.55489453016
 }
 
 func TestIpmtFloat64(t *testing.T) {
@@ -224,7 +258,38 @@ func TestIpmtFloat64(t *testing.T) {
 		assert.InDelta(t, expected, actual, DELTA)
 	})
 
-	t.Run("rate < 0", func(t *testing.T) {
+	t.Run("per is 1", func(t *testing.T) {
+		// Like Excel, a first payment due at the beginning of the period
+		// carries no interest.
+		testCases := []testData{
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, false},
+				expected: -80_000.0,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, true},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{-0.005, 1, 36, 800_000, 1_000, true},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := IpmtFloat64(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+
+	t.Run("rate <= -1", func(t *testing.T) {
 		testCases := []testData{
 			{
 				args:     testArgs{-1.0, 24, 36, 100_000, 0, false},
@@ -257,6 +322,31 @@ func TestIpmtFloat64(t *testing.T) {
 		}
 	})
 
+	t.Run("-1 < rate < 0", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{-0.005, 2, 36, 800_000, 0, false},
+				expected: 3_878.866309,
+			},
+			{
+				args:     testArgs{-0.005, 2, 36, 800_000, 0, true},
+				expected: 3_898.358100,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := IpmtFloat64(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+
 	t.Run("rate is 0", func(t *testing.T) {
 		testCases := []testData{
 			{
@@ -373,6 +463,37 @@ func TestIpmt(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("per is 1", func(t *testing.T) {
+		// Like Excel, a first payment due at the beginning of the period
+		// carries no interest.
+		testCases := []testData{
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, false},
+				expected: -80_000,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, true},
+				expected: 0,
+			},
+			{
+				args:     testArgs{-0.005, 1, 36, 800_000, 1_000, true},
+				expected: 0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Ipmt(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
 	t.Run("rate is 0", func(t *testing.T) {
 		testCases := []testData{
 			{
@@ -456,10 +577,108 @@ func TestIpmt(t *testing.T) {
 	})
 }
 
+func TestIspmtF64(t *testing.T) {
+	actual := IspmtF64(0.01, 3, 12, 1_234.56)
+	assert.InDelta(t, -9.2592, actual, DELTA)
+}
+
+func ExampleIspmtFloat64() {
+	v := IspmtFloat64(0.1/12, 1, 36, 8_000_000)
+	fmt.Printf("%.5f\n", v)
+	// Output: -64814.81481
+}
+
+func TestIspmtFloat64(t *testing.T) {
+	type testArgs struct {
+		rate float64
+		per  int
+		nper int
+		pv   int
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("nper is 0", func(t *testing.T) {
+		actual := IspmtFloat64(0.1, 1, 0, 800_000)
+		expected := 0.0
+		assert.InDelta(t, expected, actual, DELTA)
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{0.1 / 12, 1, 36, 8_000_000},
+				expected: -64_814.814815,
+			},
+			{
+				args:     testArgs{0.1, 1, 3, 8_000_000},
+				expected: -533_333.333333,
+			},
+			{
+				args:     testArgs{0.1, 0, 10, 1_000_000},
+				expected: -100_000.0,
+			},
+			{
+				args:     testArgs{0.1, 9, 10, 1_000_000},
+				expected: -10_000.0,
+			},
+			{
+				args:     testArgs{0.1, 10, 10, 1_000_000},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{0.0, 5, 10, 1_000_000},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := IspmtFloat64(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}
+
+func ExampleIspmt() {
+	v := Ispmt(0.1/12, 1, 36, 8_000_000)
+	fmt.Println(v)
+	// Output: -64815
+}
+
+func TestIspmt(t *testing.T) {
+	t.Run("nper is 0", func(t *testing.T) {
+		actual := Ispmt(0.1, 1, 0, 800_000)
+		expected := 0
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		actual := Ispmt(0.1, 1, 3, 8_000_000)
+		expected := -533_333
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestFvF64(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		expected := FvFloat64(0.01, 12, -10_025, 123_456, paymentFlag) / 100
+		actual := FvF64(0.01, 12, -100.25, 1_234.56, paymentFlag)
+		assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+	}
+}
+
 func ExampleFvFloat64() {
 	v := FvFloat64(0.1, 12, 10_000.0, 0, false)
 	fmt.Println(v)
-	// Output: -213842.83767210023
+	// Output: -213842.8376721
 }
 
 func TestFvFloat64(t *testing.T) {
@@ -626,6 +845,290 @@ func TestFv(t *testing.T) {
 	})
 }
 
+func ExampleFvscheduleFloat64() {
+	v := FvscheduleFloat64(1, []float64{0.09, 0.11, 0.1})
+	fmt.Printf("%.5f\n", v)
+	// Output: 1.33089
+}
+
+func TestFvscheduleFloat64(t *testing.T) {
+	type testArgs struct {
+		principal float64
+		rates     []float64
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	testCases := []testData{
+		{
+			args:     testArgs{1, []float64{0.09, 0.11, 0.1}},
+			expected: 1.33089,
+		},
+		{
+			args:     testArgs{10_000, []float64{0.01, 0.02, 0.03}},
+			expected: 10_611.06,
+		},
+		{
+			args:     testArgs{10_000, []float64{}},
+			expected: 10_000,
+		},
+		{
+			args:     testArgs{10_000, []float64{0, -0.5}},
+			expected: 5_000,
+		},
+	}
+	for _, testCase := range testCases {
+		args := testCase.args
+		actual := FvscheduleFloat64(args.principal, args.rates)
+		assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+	}
+}
+
+func ExampleFvschedule() {
+	v := Fvschedule(10_000, []float64{0.01, 0.02, 0.03})
+	fmt.Println(v)
+	// Output: 10611
+}
+
+func TestFvschedule(t *testing.T) {
+	actual := Fvschedule(10_000, []float64{0.01, 0.02, 0.03})
+	assert.Equal(t, 10_611, actual)
+}
+
+func TestFvRatesF64(t *testing.T) {
+	actual := FvRatesF64([]float64{0.01, 0.02, 0.03}, -10.0, 100.0, false)
+	assert.InDelta(t, -75.3046, actual, DELTA)
+}
+
+func ExampleFvRatesFloat64() {
+	v := FvRatesFloat64([]float64{0.01, 0.02, 0.03}, -1_000, 10_000, false)
+	fmt.Printf("%.2f\n", v)
+	// Output: -7530.46
+}
+
+func TestFvRatesFloat64(t *testing.T) {
+	type testArgs struct {
+		rates       []float64
+		pmt         float64
+		pv          int
+		paymentFlag bool
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("rates is empty", func(t *testing.T) {
+		actual := FvRatesFloat64([]float64{}, -1_000, 10_000, false)
+		expected := -10_000.0
+		assert.InDelta(t, expected, actual, DELTA)
+	})
+
+	t.Run("constant rate equals FvFloat64", func(t *testing.T) {
+		rates := []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}
+		for _, paymentFlag := range []bool{false, true} {
+			expected := FvFloat64(0.1, len(rates), 10_000.0, 1_000, paymentFlag)
+			actual := FvRatesFloat64(rates, 10_000.0, 1_000, paymentFlag)
+			assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{[]float64{0.01, 0.02, 0.03}, -1_000, 10_000, false},
+				expected: -7_530.46,
+			},
+			{
+				args:     testArgs{[]float64{0.01, 0.02, 0.03}, -1_000, 10_000, true},
+				expected: -7_469.354,
+			},
+			{
+				args:     testArgs{[]float64{0.0, 0.0}, -1_000, 10_000, false},
+				expected: -8_000.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := FvRatesFloat64(
+				args.rates,
+				args.pmt,
+				args.pv,
+				args.paymentFlag,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}
+
+func ExampleFvRates() {
+	v := FvRates([]float64{0.01, 0.02, 0.03}, -1_000, 10_000, false)
+	fmt.Println(v)
+	// Output: -7530
+}
+
+func TestFvRates(t *testing.T) {
+	actual := FvRates([]float64{0.01, 0.02, 0.03}, -1_000, 10_000, true)
+	assert.Equal(t, -7_469, actual)
+}
+
+func TestPdurationF64(t *testing.T) {
+	actual := PdurationF64(0.05, 1_234.56, 2_469.12)
+	assert.InDelta(t, 14.206699, actual, DELTA)
+}
+
+func ExamplePduration() {
+	v := Pduration(0.025, 2_000, 2_200)
+	fmt.Printf("%.5f\n", v)
+	// Output: 3.85987
+}
+
+func TestPduration(t *testing.T) {
+	type testArgs struct {
+		rate float64
+		pv   int
+		fv   int
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("rate <= 0 || pv <= 0 || fv <= 0", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{0.0, 2_000, 2_200},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{-0.025, 2_000, 2_200},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{0.025, 0, 2_200},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{0.025, 2_000, -2_200},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Pduration(args.rate, args.pv, args.fv)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{0.025, 2_000, 2_200},
+				expected: 3.859866,
+			},
+			{
+				args:     testArgs{0.00025, 50, 60},
+				expected: 729.377384,
+			},
+			{
+				args:     testArgs{0.1, 1_000, 1_000},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Pduration(args.rate, args.pv, args.fv)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}
+
+func TestRriF64(t *testing.T) {
+	actual := RriF64(10, 1_234.56, 2_469.12)
+	assert.InDelta(t, 0.071773, actual, DELTA)
+}
+
+func ExampleRri() {
+	v := Rri(96, 10_000, 30_000)
+	fmt.Printf("%.5f\n", v)
+	// Output: 0.01151
+}
+
+func TestRri(t *testing.T) {
+	type testArgs struct {
+		nper int
+		pv   int
+		fv   int
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{0, 10_000, 30_000},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{-1, 10_000, 30_000},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{96, 0, 30_000},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{2, 10_000, -30_000},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Rri(args.nper, args.pv, args.fv)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{96, 10_000, 30_000},
+				expected: 0.011510,
+			},
+			{
+				args:     testArgs{2, 200, 100},
+				expected: -0.292893,
+			},
+			{
+				args:     testArgs{5, 1_000, 1_000},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Rri(args.nper, args.pv, args.fv)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}
+
+func TestPpmtF64(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		pmt := PmtF64(0.01, 12, 1_234.56, 0.5, paymentFlag)
+		ipmt := IpmtF64(0.01, 3, 12, 1_234.56, 0.5, paymentFlag)
+		actual := PpmtF64(0.01, 3, 12, 1_234.56, 0.5, paymentFlag)
+		assert.InDelta(t, pmt-ipmt, actual, DELTA, paymentFlag)
+	}
+}
+
 func ExamplePpmtFloat64() {
 	v := PpmtFloat64(0.1, 12, 36, 800_000, 0, false)
 	fmt.Printf("%.5f\n", v)
@@ -672,6 +1175,37 @@ func TestPpmtFloat64(t *testing.T) {
 		}
 	})
 
+	t.Run("per is 1", func(t *testing.T) {
+		// Like Excel, a first payment due at the beginning of the period
+		// carries no interest.
+		testCases := []testData{
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, false},
+				expected: -2_674.451055,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, true},
+				expected: -75_158.591868,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 1_000, true},
+				expected: -75_161.631017,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := PpmtFloat64(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+
 	t.Run("per >= nper + 1", func(t *testing.T) {
 		testCases := []testData{
 			{
@@ -777,6 +1311,37 @@ func TestPpmt(t *testing.T) {
 		}
 	})
 
+	t.Run("per is 1", func(t *testing.T) {
+		// Like Excel, a first payment due at the beginning of the period
+		// carries no interest.
+		testCases := []testData{
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, false},
+				expected: -2_674,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 0, true},
+				expected: -75_159,
+			},
+			{
+				args:     testArgs{0.1, 1, 36, 800_000, 1_000, true},
+				expected: -75_162,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := Ppmt(
+				args.rate,
+				args.per,
+				args.nper,
+				args.pv,
+				args.fv,
+				args.paymentFlag,
+			)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
 	t.Run("per >= nper + 1", func(t *testing.T) {
 		testCases := []testData{
 			{
@@ -836,10 +1401,18 @@ func TestPpmt(t *testing.T) {
 	})
 }
 
+func TestCumipmtF64(t *testing.T) {
+	for _, paymentFlag := range []bool{false, true} {
+		expected := CumipmtFloat64(0.01, 12, 123_456, 2, 6, paymentFlag) / 100
+		actual := CumipmtF64(0.01, 12, 1_234.56, 2, 6, paymentFlag)
+		assert.InDelta(t, expected, actual, DELTA, paymentFlag)
+	}
+}
+
 func ExampleCumipmtFloat64() {
 	v := CumipmtFloat64(0.1, 36, 800_000, 6, 12, true)
 	fmt.Println(v)
-	// Output: -488961.5711288557
+	// Output: -488961.57112885575
 }
 
 func TestCumipmtFloat64(t *testing.T) {
@@ -857,7 +1430,7 @@ func TestCumipmtFloat64(t *testing.T) {
 		expected float64
 	}
 
-	t.Run("rate <= 0.0", func(t *testing.T) {
+	t.Run("rate is 0 or rate <= -1", func(t *testing.T) {
 		testCases := []testData{
 			{
 				args:     testArgs{0, 36, 800_000, 6, 12, false},
@@ -882,6 +1455,31 @@ func TestCumipmtFloat64(t *testing.T) {
 		}
 	})
 
+	t.Run("-1 < rate < 0", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{-0.005, 36, 800_000, 6, 12, false},
+				expected: 21_342.231717,
+			},
+			{
+				args:     testArgs{-0.005, 36, 800_000, 6, 12, true},
+				expected: 21_449.479112,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := CumipmtFloat64(
+				args.rate,
+				args.nper,
+				args.pv,
+				args.start,
+				args.end,
+				args.paymentFlag,
+			)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+
 	t.Run("nper <= 0.0", func(t *testing.T) {
 		testCases := []testData{
 			{
@@ -1016,7 +1614,7 @@ func TestCumipmt(t *testing.T) {
 		expected int
 	}
 
-	t.Run("rate <= 0.0", func(t *testing.T) {
+	t.Run("rate is 0 or rate <= -1", func(t *testing.T) {
 		testCases := []testData{
 			{
 				args:     testArgs{0, 36, 800_000, 6, 12, false},
@@ -1153,3 +1751,151 @@ func TestCumipmt(t *testing.T) {
 		}
 	})
 }
+
+func ExampleDollarDe() {
+	v := DollarDe(1.02, 16)
+	fmt.Printf("%.4f\n", v)
+	// Output: 1.1250
+}
+
+func TestDollarDe(t *testing.T) {
+	type testArgs struct {
+		fractionalDollar float64
+		fraction         float64
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("fraction < 1", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{1.02, 0},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{1.02, 0.9},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{1.02, -16},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := DollarDe(args.fractionalDollar, args.fraction)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{1.02, 16},
+				expected: 1.125,
+			},
+			{
+				args:     testArgs{1.1, 32},
+				expected: 1.3125,
+			},
+			{
+				args:     testArgs{1.1, 32.9},
+				expected: 1.3125,
+			},
+			{
+				args:     testArgs{-1.02, 16},
+				expected: -1.125,
+			},
+			{
+				args:     testArgs{99.31, 32},
+				expected: 99.96875,
+			},
+			{
+				args:     testArgs{1.5, 10},
+				expected: 1.5,
+			},
+			{
+				args:     testArgs{1.5, 1},
+				expected: 1.5,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := DollarDe(args.fractionalDollar, args.fraction)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}
+
+func ExampleDollarFr() {
+	v := DollarFr(1.125, 16)
+	fmt.Printf("%.4f\n", v)
+	// Output: 1.0200
+}
+
+func TestDollarFr(t *testing.T) {
+	type testArgs struct {
+		decimalDollar float64
+		fraction      float64
+	}
+
+	type testData struct {
+		args     testArgs
+		expected float64
+	}
+
+	t.Run("fraction < 1", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{1.125, 0},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{1.125, 0.9},
+				expected: 0.0,
+			},
+			{
+				args:     testArgs{1.125, -16},
+				expected: 0.0,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := DollarFr(args.decimalDollar, args.fraction)
+			assert.Equal(t, testCase.expected, actual, testCase)
+		}
+	})
+
+	t.Run("Calculate", func(t *testing.T) {
+		testCases := []testData{
+			{
+				args:     testArgs{1.125, 16},
+				expected: 1.02,
+			},
+			{
+				args:     testArgs{1.125, 32},
+				expected: 1.04,
+			},
+			{
+				args:     testArgs{1.125, 32.9},
+				expected: 1.04,
+			},
+			{
+				args:     testArgs{-1.125, 16},
+				expected: -1.02,
+			},
+			{
+				args:     testArgs{99.96875, 32},
+				expected: 99.31,
+			},
+		}
+		for _, testCase := range testCases {
+			args := testCase.args
+			actual := DollarFr(args.decimalDollar, args.fraction)
+			assert.InDelta(t, testCase.expected, actual, DELTA, testCase)
+		}
+	})
+}